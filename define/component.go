@@ -20,6 +20,7 @@
 package define
 
 import (
+	"git.golaxy.org/core/ec"
 	"git.golaxy.org/core/ec/pt"
 	"git.golaxy.org/core/utils/types"
 	"github.com/elliotchance/pie/v2"
@@ -45,3 +46,30 @@ type ComponentDefinition struct {
 	Prototype string // 组件原型名称
 	Name      string // 组件名称
 }
+
+// ComponentT 定义组件，并提供类型安全的组件访问，COMP需要为组件的指针类型
+func ComponentT[COMP ec.Component](name ...string) ComponentDefinitionT[COMP] {
+	return ComponentDefinitionT[COMP]{
+		ComponentDefinition: Component[COMP](name...),
+	}
+}
+
+// ComponentDefinitionT 类型安全的组件定义
+type ComponentDefinitionT[COMP ec.Component] struct {
+	ComponentDefinition
+}
+
+// Get 查询实体中的组件
+func (def ComponentDefinitionT[COMP]) Get(entity ec.Entity) (COMP, bool) {
+	return ec.GetComponentT[COMP](entity, def.Name)
+}
+
+// Require 查询实体中的组件，组件不存在或类型不匹配时panic
+func (def ComponentDefinitionT[COMP]) Require(entity ec.Entity) COMP {
+	return ec.RequireComponentT[COMP](entity, def.Name)
+}
+
+// Ref 创建实体中的组件引用
+func (def ComponentDefinitionT[COMP]) Ref(entity ec.Entity) ec.ComponentRef[COMP] {
+	return ec.MakeComponentRef[COMP](entity, def.Name)
+}
@@ -0,0 +1,68 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package define
+
+import (
+	"fmt"
+	"git.golaxy.org/core/extension"
+	"git.golaxy.org/core/utils/iface"
+	"git.golaxy.org/core/utils/types"
+)
+
+// Injected 注入的插件，实体或组件中此类型的字段，将在实体加入运行时或组件添加至运行时中的实体时，依次从运行时与服务的插件管理器注入，插件缺失时创建实体或添加组件失败，
+// 可以使用标签`golaxy:"addin=<name>,optional"`指定插件名称或设置为可选插件
+type Injected[T any] struct {
+	addIn    T
+	injected bool
+}
+
+// Get 获取插件
+func (i Injected[T]) Get() T {
+	return i.addIn
+}
+
+// IsInjected 是否已注入插件
+func (i Injected[T]) IsInjected() bool {
+	return i.injected
+}
+
+// InjectAddIn 依次从插件提供者中查找并注入插件，name为空时使用插件接口类型名称
+func (i *Injected[T]) InjectAddIn(name string, providers ...extension.AddInProvider) error {
+	if name == "" {
+		name = types.FullNameT[T]()
+	}
+
+	for _, provider := range providers {
+		if provider == nil {
+			continue
+		}
+
+		status, ok := provider.GetAddInManager().Get(name)
+		if !ok || status.State() != extension.AddInState_Active {
+			continue
+		}
+
+		i.addIn = iface.Cache2Iface[T](status.InstanceFace().Cache)
+		i.injected = true
+		return nil
+	}
+
+	return fmt.Errorf("%w: addIn %q not installed or not actived", extension.ErrExtension, name)
+}
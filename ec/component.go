@@ -40,6 +40,8 @@ type Component interface {
 
 	// GetId 获取组件Id
 	GetId() uid.Id
+	// GetHandle 获取组件句柄，用于安全的跨帧弱引用组件
+	GetHandle() ComponentHandle
 	// GetBuiltin 获取实体原型中的组件信息
 	GetBuiltin() BuiltinComponent
 	// GetName 获取组件名称
@@ -56,6 +58,14 @@ type Component interface {
 	GetEnable() bool
 	// SetEnable 设置组件是否启用
 	SetEnable(b bool)
+	// GetProperties 获取组件的所有属性（Property）
+	GetProperties() []IProperty
+	// GetProperty 查询组件属性（Property）
+	GetProperty(name string) (IProperty, bool)
+	// GetDirtyProperties 获取本帧改变过的属性名称
+	GetDirtyProperties() []string
+	// IsDirty 本帧是否有属性改变过
+	IsDirty() bool
 	// ManagedAddHooks 托管事件钩子（event.Hook），在组件销毁时自动解绑定
 	ManagedAddHooks(hooks ...event.Hook)
 	// ManagedAddTagHooks 根据标签托管事件钩子（event.Hook），在组件销毁时自动解绑定
@@ -74,12 +84,17 @@ type iComponent interface {
 	init(name string, entity Entity, instance Component)
 	withContext(ctx context.Context)
 	setId(id uid.Id)
+	setNode(node *generic.Node[Component])
+	getNode() *generic.Node[Component]
 	setBuiltin(builtin *BuiltinComponent)
 	setState(state ComponentState)
 	setReflected(v reflect.Value)
 	setRemovable(b bool)
+	reset()
 	getCallingStateBits() *types.Bits16
 	getProcessedStateBits() *types.Bits16
+	markPropertyDirty(property string)
+	clearDirty()
 	managedCleanAllHooks()
 }
 
@@ -92,6 +107,7 @@ type ComponentBehavior struct {
 	builtin            *BuiltinComponent
 	name               string
 	entity             Entity
+	node               *generic.Node[Component]
 	instance           Component
 	state              ComponentState
 	reflected          reflect.Value
@@ -99,6 +115,9 @@ type ComponentBehavior struct {
 	enable             bool
 	callingStateBits   types.Bits16
 	processedStateBits types.Bits16
+	generation         int64
+	properties         []IProperty
+	dirtyProperties    []string
 	managedHooks       []event.Hook
 	managedTagHooks    generic.SliceMap[string, []event.Hook]
 
@@ -110,6 +129,18 @@ func (comp *ComponentBehavior) GetId() uid.Id {
 	return comp.id
 }
 
+// GetHandle 获取组件句柄，用于安全的跨帧弱引用组件
+func (comp *ComponentBehavior) GetHandle() ComponentHandle {
+	handle := ComponentHandle{
+		Id:         comp.id,
+		Generation: comp.generation,
+	}
+	if comp.entity != nil {
+		handle.Entity = comp.entity.GetHandle()
+	}
+	return handle
+}
+
 // GetBuiltin 获取实体原型中的组件信息
 func (comp *ComponentBehavior) GetBuiltin() BuiltinComponent {
 	if comp.builtin == nil {
@@ -173,6 +204,11 @@ func (comp *ComponentBehavior) EventComponentEnableChanged() event.IEvent {
 	return comp.componentEventTab.EventComponentEnableChanged()
 }
 
+// EventComponentPropertyChanged 事件：组件属性改变
+func (comp *ComponentBehavior) EventComponentPropertyChanged() event.IEvent {
+	return comp.componentEventTab.EventComponentPropertyChanged()
+}
+
 // EventComponentDestroySelf 事件：组件销毁自身
 func (comp *ComponentBehavior) EventComponentDestroySelf() event.IEvent {
 	return comp.componentEventTab.EventComponentDestroySelf()
@@ -204,6 +240,8 @@ func (comp *ComponentBehavior) init(name string, entity Entity, instance Compone
 	comp.instance = instance
 	comp.removable = true
 	comp.enable = true
+	comp.generation = nextGeneration()
+	comp.properties = bindProperties(instance)
 	comp.componentEventTab.Init(false, nil, event.EventRecursion_Allow)
 	comp.setState(ComponentState_Birth)
 }
@@ -214,7 +252,24 @@ func (comp *ComponentBehavior) withContext(ctx context.Context) {
 }
 
 func (comp *ComponentBehavior) setId(id uid.Id) {
+	if comp.id == id {
+		return
+	}
+
+	old := comp.id
 	comp.id = id
+
+	if comp.entity != nil {
+		comp.entity.changeComponentId(comp.instance, old)
+	}
+}
+
+func (comp *ComponentBehavior) setNode(node *generic.Node[Component]) {
+	comp.node = node
+}
+
+func (comp *ComponentBehavior) getNode() *generic.Node[Component] {
+	return comp.node
 }
 
 func (comp *ComponentBehavior) setBuiltin(builtin *BuiltinComponent) {
@@ -252,6 +307,10 @@ func (comp *ComponentBehavior) setRemovable(b bool) {
 	comp.removable = b
 }
 
+func (comp *ComponentBehavior) reset() {
+	*comp = ComponentBehavior{}
+}
+
 func (comp *ComponentBehavior) getCallingStateBits() *types.Bits16 {
 	return &comp.callingStateBits
 }
@@ -71,6 +71,52 @@ func (h EventComponentEnableChangedHandler) OnComponentEnableChanged(comp Compon
 	h(comp, enable)
 }
 
+type iAutoEventComponentPropertyChanged interface {
+	EventComponentPropertyChanged() event.IEvent
+}
+
+func BindEventComponentPropertyChanged(auto iAutoEventComponentPropertyChanged, subscriber EventComponentPropertyChanged, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventComponentPropertyChanged](auto.EventComponentPropertyChanged(), subscriber, priority...)
+}
+
+func _EmitEventComponentPropertyChanged(auto iAutoEventComponentPropertyChanged, comp Component, property string) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventComponentPropertyChanged()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventComponentPropertyChanged](subscriber).OnComponentPropertyChanged(comp, property)
+		return true
+	})
+}
+
+func _EmitEventComponentPropertyChangedWithInterrupt(auto iAutoEventComponentPropertyChanged, interrupt func(comp Component, property string) bool, comp Component, property string) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventComponentPropertyChanged()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(comp, property) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventComponentPropertyChanged](subscriber).OnComponentPropertyChanged(comp, property)
+		return true
+	})
+}
+
+func HandleEventComponentPropertyChanged(fun func(comp Component, property string)) EventComponentPropertyChangedHandler {
+	return EventComponentPropertyChangedHandler(fun)
+}
+
+type EventComponentPropertyChangedHandler func(comp Component, property string)
+
+func (h EventComponentPropertyChangedHandler) OnComponentPropertyChanged(comp Component, property string) {
+	h(comp, property)
+}
+
 type iAutoEventComponentDestroySelf interface {
 	EventComponentDestroySelf() event.IEvent
 }
@@ -28,6 +28,12 @@ type EventComponentEnableChanged interface {
 	OnComponentEnableChanged(comp Component, enable bool)
 }
 
+// EventComponentPropertyChanged 事件：组件属性改变
+// +event-gen:export=0
+type EventComponentPropertyChanged interface {
+	OnComponentPropertyChanged(comp Component, property string)
+}
+
 // EventComponentDestroySelf 事件：组件销毁自身
 // +event-gen:export=0
 // +event-tab-gen:recursion=discard
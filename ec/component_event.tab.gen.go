@@ -27,20 +27,23 @@ import (
 
 type IComponentEventTab interface {
 	EventComponentEnableChanged() event.IEvent
+	EventComponentPropertyChanged() event.IEvent
 	EventComponentDestroySelf() event.IEvent
 }
 
 var (
 	_componentEventTabId = event.DeclareEventTabIdT[componentEventTab]()
 	EventComponentEnableChangedId = _componentEventTabId + 0
-	EventComponentDestroySelfId = _componentEventTabId + 1
+	EventComponentPropertyChangedId = _componentEventTabId + 1
+	EventComponentDestroySelfId = _componentEventTabId + 2
 )
 
-type componentEventTab [2]event.Event
+type componentEventTab [3]event.Event
 
 func (eventTab *componentEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
 	(*eventTab)[0].Init(autoRecover, reportError, event.EventRecursion_Deepest)
-	(*eventTab)[1].Init(autoRecover, reportError, event.EventRecursion_Discard)
+	(*eventTab)[1].Init(autoRecover, reportError, recursion)
+	(*eventTab)[2].Init(autoRecover, reportError, event.EventRecursion_Discard)
 }
 
 func (eventTab *componentEventTab) Open() {
@@ -80,6 +83,10 @@ func (eventTab *componentEventTab) EventComponentEnableChanged() event.IEvent {
 	return &(*eventTab)[0]
 }
 
-func (eventTab *componentEventTab) EventComponentDestroySelf() event.IEvent {
+func (eventTab *componentEventTab) EventComponentPropertyChanged() event.IEvent {
 	return &(*eventTab)[1]
 }
+
+func (eventTab *componentEventTab) EventComponentDestroySelf() event.IEvent {
+	return &(*eventTab)[2]
+}
@@ -0,0 +1,69 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"slices"
+)
+
+// GetProperties 获取组件的所有属性（Property）
+func (comp *ComponentBehavior) GetProperties() []IProperty {
+	return slices.Clone(comp.properties)
+}
+
+// GetProperty 查询组件属性（Property）
+func (comp *ComponentBehavior) GetProperty(name string) (IProperty, bool) {
+	idx := slices.IndexFunc(comp.properties, func(property IProperty) bool {
+		return property.GetName() == name
+	})
+	if idx < 0 {
+		return nil, false
+	}
+	return comp.properties[idx], true
+}
+
+// GetDirtyProperties 获取本帧改变过的属性名称
+func (comp *ComponentBehavior) GetDirtyProperties() []string {
+	return slices.Clone(comp.dirtyProperties)
+}
+
+// IsDirty 本帧是否有属性改变过
+func (comp *ComponentBehavior) IsDirty() bool {
+	return len(comp.dirtyProperties) > 0
+}
+
+func (comp *ComponentBehavior) markPropertyDirty(property string) {
+	if comp.entity == nil || comp.state > ComponentState_Alive {
+		return
+	}
+
+	if !slices.Contains(comp.dirtyProperties, property) {
+		comp.dirtyProperties = append(comp.dirtyProperties, property)
+		if len(comp.dirtyProperties) == 1 {
+			comp.entity.markComponentDirty(comp.instance)
+		}
+	}
+
+	_EmitEventComponentPropertyChanged(comp, comp.instance, property)
+}
+
+func (comp *ComponentBehavior) clearDirty() {
+	comp.dirtyProperties = comp.dirtyProperties[:0]
+}
@@ -0,0 +1,47 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"git.golaxy.org/core/utils/exception"
+	"sync/atomic"
+)
+
+var debugMode atomic.Bool
+
+// SetDebugMode 设置调试模式，开启后修改已销毁（Destroyed）实体的组件时将会panic，用于排查持有失效实体引用的问题
+func SetDebugMode(b bool) {
+	debugMode.Store(b)
+}
+
+// GetDebugMode 获取是否开启调试模式
+func GetDebugMode() bool {
+	return debugMode.Load()
+}
+
+func (entity *EntityBehavior) debugCheckDestroyed() {
+	if !debugMode.Load() {
+		return
+	}
+	// 回收至对象池的实体将被重置，状态回到出生（Birth），但代数（generation）为0，只有初始化后的实体代数不为0
+	if entity.state >= EntityState_Destroyed || entity.generation == 0 {
+		exception.Panicf("%w: entity %q is destroyed or released, component manager can't be mutated", ErrEC, entity.GetId())
+	}
+}
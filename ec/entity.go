@@ -32,6 +32,7 @@ import (
 	"git.golaxy.org/core/utils/types"
 	"git.golaxy.org/core/utils/uid"
 	"reflect"
+	"time"
 )
 
 // NewEntity 创建实体
@@ -67,6 +68,8 @@ type Entity interface {
 	GetId() uid.Id
 	// GetPT 获取实体原型信息
 	GetPT() EntityPT
+	// GetHandle 获取实体句柄，用于安全的跨帧弱引用实体
+	GetHandle() EntityHandle
 	// GetScope 获取可访问作用域
 	GetScope() Scope
 	// GetState 获取实体状态
@@ -75,6 +78,16 @@ type Entity interface {
 	GetReflected() reflect.Value
 	// GetMeta 获取Meta信息
 	GetMeta() meta.Meta
+	// GetActive 获取实体自身是否激活
+	GetActive() bool
+	// GetActiveInHierarchy 获取实体在实体树中是否激活，父实体未激活时，子实体也不激活
+	GetActiveInHierarchy() bool
+	// SetActive 设置实体自身是否激活，未激活的实体，组件将会关闭（OnDisable），不再收到帧更新，重新激活后恢复组件原有的启用状态
+	SetActive(b bool)
+	// GetDirtyComponents 获取本帧属性（Property）改变过的组件
+	GetDirtyComponents() []Component
+	// IsDirty 本帧是否有组件属性（Property）改变过
+	IsDirty() bool
 	// ManagedAddHooks 托管事件钩子（event.Hook），在实体销毁时自动解绑定
 	ManagedAddHooks(hooks ...event.Hook)
 	// ManagedAddTagHooks 根据标签托管事件钩子（event.Hook），在实体销毁时自动解绑定
@@ -85,6 +98,10 @@ type Entity interface {
 	ManagedCleanTagHooks(tag string)
 	// DestroySelf 销毁自身
 	DestroySelf()
+	// DestroyAfter 延迟指定时长后销毁自身
+	DestroyAfter(delay time.Duration)
+	// DestroyAtFrameEnd 在帧结束时销毁自身，无帧模式下在当前任务结束时销毁自身
+	DestroyAtFrameEnd()
 
 	IEntityEventTab
 }
@@ -96,11 +113,21 @@ type iEntity interface {
 	setId(id uid.Id)
 	setPT(prototype EntityPT)
 	setContext(ctx iface.Cache)
+	setComponentInjector(injector generic.Func1[Component, error])
 	getVersion() int64
 	setState(state EntityState)
 	setReflected(v reflect.Value)
 	getProcessedStateBits() *types.Bits16
+	setLifecycleError(err error)
+	getLifecycleError() error
+	updateActiveInHierarchy()
+	rangeAllComponents(fun generic.Func1[Component, bool])
+	changeComponentId(comp Component, old uid.Id)
+	markComponentDirty(comp Component)
+	clearDirty()
+	reset()
 	managedCleanAllHooks()
+	debugCheckDestroyed()
 }
 
 // EntityBehavior 实体行为，在扩展实体能力时，匿名嵌入至实体结构体中
@@ -111,14 +138,22 @@ type EntityBehavior struct {
 	opts               EntityOptions
 	prototype          EntityPT
 	context            iface.Cache
+	componentInjector  generic.Func1[Component, error]
 	componentNameIndex generic.SliceMap[string, *generic.Node[Component]]
+	componentIdIndex   map[uid.Id]*generic.Node[Component]
+	componentPTIndex   map[string]*generic.Node[Component]
 	components         generic.List[Component]
 	state              EntityState
 	reflected          reflect.Value
+	active             bool
+	activeInHierarchy  bool
 	treeNodeState      TreeNodeState
 	treeNodeParent     Entity
 	callingStateBits   types.Bits16
 	processedStateBits types.Bits16
+	lifecycleErr       error
+	generation         int64
+	dirtyComponents    []Component
 	managedHooks       []event.Hook
 	managedTagHooks    generic.SliceMap[string, []event.Hook]
 
@@ -140,6 +175,14 @@ func (entity *EntityBehavior) GetPT() EntityPT {
 	return entity.prototype
 }
 
+// GetHandle 获取实体句柄，用于安全的跨帧弱引用实体
+func (entity *EntityBehavior) GetHandle() EntityHandle {
+	return EntityHandle{
+		Id:         entity.GetId(),
+		Generation: entity.generation,
+	}
+}
+
 // GetScope 获取可访问作用域
 func (entity *EntityBehavior) GetScope() Scope {
 	return entity.opts.Scope
@@ -164,16 +207,62 @@ func (entity *EntityBehavior) GetMeta() meta.Meta {
 	return entity.opts.Meta
 }
 
+// GetActive 获取实体自身是否激活
+func (entity *EntityBehavior) GetActive() bool {
+	return entity.active
+}
+
+// GetActiveInHierarchy 获取实体在实体树中是否激活，父实体未激活时，子实体也不激活
+func (entity *EntityBehavior) GetActiveInHierarchy() bool {
+	return entity.activeInHierarchy
+}
+
+// SetActive 设置实体自身是否激活，未激活的实体，组件将会关闭（OnDisable），不再收到帧更新，重新激活后恢复组件原有的启用状态
+func (entity *EntityBehavior) SetActive(b bool) {
+	if entity.active == b {
+		return
+	}
+
+	entity.active = b
+
+	entity.updateActiveInHierarchy()
+}
+
 // DestroySelf 销毁自身
 func (entity *EntityBehavior) DestroySelf() {
 	_EmitEventEntityDestroySelf(entity, entity.opts.InstanceFace.Iface)
 }
 
+// DestroyAfter 延迟指定时长后销毁自身
+func (entity *EntityBehavior) DestroyAfter(delay time.Duration) {
+	_EmitEventEntityDestroyDeferred(entity, entity.opts.InstanceFace.Iface, delay)
+}
+
+// DestroyAtFrameEnd 在帧结束时销毁自身，无帧模式下在当前任务结束时销毁自身
+func (entity *EntityBehavior) DestroyAtFrameEnd() {
+	_EmitEventEntityDestroyDeferred(entity, entity.opts.InstanceFace.Iface, 0)
+}
+
 // EventEntityDestroySelf 事件：实体销毁自身
 func (entity *EntityBehavior) EventEntityDestroySelf() event.IEvent {
 	return entity.entityEventTab.EventEntityDestroySelf()
 }
 
+// EventEntityDestroyDeferred 事件：实体延迟销毁自身
+func (entity *EntityBehavior) EventEntityDestroyDeferred() event.IEvent {
+	return entity.entityEventTab.EventEntityDestroyDeferred()
+}
+
+// EventEntityDirty 事件：实体的组件属性在本帧内首次改变，实体成为脏实体
+func (entity *EntityBehavior) EventEntityDirty() event.IEvent {
+	return entity.entityEventTab.EventEntityDirty()
+}
+
+// EventEntityActiveChanged 事件：实体在实体树中的激活状态改变
+func (entity *EntityBehavior) EventEntityActiveChanged() event.IEvent {
+	return entity.entityEventTab.EventEntityActiveChanged()
+}
+
 // GetCurrentContext 获取当前上下文
 func (entity *EntityBehavior) GetCurrentContext() iface.Cache {
 	return entity.context
@@ -201,6 +290,10 @@ func (entity *EntityBehavior) init(opts EntityOptions) {
 		entity.opts.InstanceFace = iface.MakeFaceT[Entity](entity)
 	}
 
+	entity.active = true
+	entity.activeInHierarchy = true
+	entity.generation = nextGeneration()
+
 	entity.entityEventTab.Init(false, nil, event.EventRecursion_Allow)
 	entity.entityComponentManagerEventTab.Init(false, nil, event.EventRecursion_Allow)
 	entity.entityTreeNodeEventTab.Init(false, nil, event.EventRecursion_Allow)
@@ -229,6 +322,10 @@ func (entity *EntityBehavior) setContext(ctx iface.Cache) {
 	entity.context = ctx
 }
 
+func (entity *EntityBehavior) setComponentInjector(injector generic.Func1[Component, error]) {
+	entity.componentInjector = injector
+}
+
 func (entity *EntityBehavior) getVersion() int64 {
 	return entity.components.Version()
 }
@@ -260,3 +357,36 @@ func (entity *EntityBehavior) setReflected(v reflect.Value) {
 func (entity *EntityBehavior) getProcessedStateBits() *types.Bits16 {
 	return &entity.processedStateBits
 }
+
+func (entity *EntityBehavior) setLifecycleError(err error) {
+	entity.lifecycleErr = err
+}
+
+func (entity *EntityBehavior) getLifecycleError() error {
+	return entity.lifecycleErr
+}
+
+func (entity *EntityBehavior) rangeAllComponents(fun generic.Func1[Component, bool]) {
+	entity.components.Traversal(func(compNode *generic.Node[Component]) bool {
+		return fun.UnsafeCall(compNode.V)
+	})
+}
+
+func (entity *EntityBehavior) reset() {
+	*entity = EntityBehavior{}
+}
+
+func (entity *EntityBehavior) updateActiveInHierarchy() {
+	active := entity.active
+	if active && entity.treeNodeParent != nil {
+		active = entity.treeNodeParent.GetActiveInHierarchy()
+	}
+
+	if entity.activeInHierarchy == active {
+		return
+	}
+
+	entity.activeInHierarchy = active
+
+	_EmitEventEntityActiveChanged(entity, entity.opts.InstanceFace.Iface, active)
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+)
+
+// Broadcast 向实体中所有实现接口I的组件广播消息，按组件顺序调用，只有活跃（Alive）的组件才会收到消息
+func Broadcast[I any](entity Entity, fun generic.Action1[I]) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	rangeReceivers[I](entity, func(receiver I) bool {
+		fun.UnsafeCall(receiver)
+		return true
+	})
+}
+
+// BroadcastFirst 向实体中所有实现接口I的组件广播消息，首个返回true的组件响应后停止广播，返回是否有组件响应
+func BroadcastFirst[I any](entity Entity, fun generic.Func1[I, bool]) bool {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	handled := false
+
+	rangeReceivers[I](entity, func(receiver I) bool {
+		handled = fun.UnsafeCall(receiver)
+		return !handled
+	})
+
+	return handled
+}
+
+// BroadcastCollect 向实体中所有实现接口I的组件广播消息，并收集所有组件的返回值
+func BroadcastCollect[I, R any](entity Entity, fun generic.Func1[I, R]) []R {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	var rets []R
+
+	rangeReceivers[I](entity, func(receiver I) bool {
+		rets = append(rets, fun.UnsafeCall(receiver))
+		return true
+	})
+
+	return rets
+}
+
+func rangeReceivers[I any](entity Entity, fun func(receiver I) bool) {
+	entity.RangeComponents(func(comp Component) bool {
+		if comp.GetState() != ComponentState_Alive {
+			return true
+		}
+
+		receiver, ok := comp.(I)
+		if !ok {
+			return true
+		}
+
+		return fun(receiver)
+	})
+}
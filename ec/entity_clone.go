@@ -0,0 +1,199 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"fmt"
+	"git.golaxy.org/core/utils/exception"
+	"reflect"
+)
+
+// ComponentCloner 组件实现此接口，可以自定义克隆组件状态
+type ComponentCloner interface {
+	// CloneFrom 从源组件克隆状态
+	CloneFrom(src Component) error
+}
+
+// CloneComponentState 克隆组件状态，目标组件实现ComponentCloner接口时使用接口克隆，否则深拷贝所有导出字段，标记了`golaxy:"noclone"`的字段不会拷贝
+func CloneComponentState(dst, src Component) error {
+	if dst == nil {
+		exception.Panicf("%w: %w: dst is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if src == nil {
+		exception.Panicf("%w: %w: src is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if cloner, ok := dst.(ComponentCloner); ok {
+		return cloner.CloneFrom(src)
+	}
+
+	dstRV := reflect.ValueOf(dst)
+	srcRV := reflect.ValueOf(src)
+
+	if dstRV.Type() != srcRV.Type() {
+		return fmt.Errorf("%w: component type %q mismatch %q", ErrEC, dstRV.Type(), srcRV.Type())
+	}
+
+	for dstRV.Kind() == reflect.Pointer {
+		dstRV = dstRV.Elem()
+		srcRV = srcRV.Elem()
+	}
+
+	if dstRV.Kind() != reflect.Struct {
+		return nil
+	}
+
+	visited := map[uintptr]reflect.Value{}
+
+	for i := 0; i < dstRV.NumField(); i++ {
+		field := dstRV.Type().Field(i)
+
+		if !field.IsExported() || field.Type == componentBehaviorRT || hasTagOption(field.Tag.Get("golaxy"), "noclone") {
+			continue
+		}
+
+		dstRV.Field(i).Set(cloneValue(srcRV.Field(i), visited))
+	}
+
+	// 属性（Property）随字段一同拷贝，需要重新绑定至目标组件
+	bindProperties(dst)
+
+	return nil
+}
+
+// CloneEntityState 克隆实体状态，不包含子实体，只能在目标实体唤醒（Awake）前调用，源实体中的组件按名称与原型依次匹配目标实体中的组件
+func CloneEntityState(dst, src Entity) error {
+	if dst == nil {
+		exception.Panicf("%w: %w: dst is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if src == nil {
+		exception.Panicf("%w: %w: src is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if dst.GetState() > EntityState_Enter {
+		return fmt.Errorf("%w: invalid entity %q state %q", ErrEC, dst.GetId(), dst.GetState())
+	}
+
+	if dst.GetPT().Prototype() != src.GetPT().Prototype() {
+		return fmt.Errorf("%w: entity prototype %q mismatch %q", ErrEC, dst.GetPT().Prototype(), src.GetPT().Prototype())
+	}
+
+	comps := dst.GetComponents()
+
+	var err error
+
+	src.RangeComponents(func(srcComp Component) bool {
+		idx := -1
+		for j, comp := range comps {
+			if comp != nil && comp.GetName() == srcComp.GetName() && comp.GetBuiltin().PT.Prototype() == srcComp.GetBuiltin().PT.Prototype() {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			err = fmt.Errorf("%w: entity %q component %q(%q) not found", ErrEC, dst.GetId(), srcComp.GetName(), srcComp.GetBuiltin().PT.Prototype())
+			return false
+		}
+
+		comp := comps[idx]
+		comps[idx] = nil
+
+		if err = CloneComponentState(comp, srcComp); err != nil {
+			err = fmt.Errorf("%w: clone entity %q component %q state failed, %w", ErrEC, src.GetId(), srcComp.GetName(), err)
+			return false
+		}
+
+		comp.SetEnable(srcComp.GetEnable())
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	dst.SetActive(src.GetActive())
+
+	return nil
+}
+
+var (
+	componentBehaviorRT = reflect.TypeFor[ComponentBehavior]()
+	componentRT         = reflect.TypeFor[Component]()
+	entityRT            = reflect.TypeFor[Entity]()
+)
+
+func cloneValue(src reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() || src.Type().Implements(componentRT) || src.Type().Implements(entityRT) {
+			return src
+		}
+		if dst, ok := visited[src.Pointer()]; ok {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		visited[src.Pointer()] = dst
+		dst.Elem().Set(cloneValue(src.Elem(), visited))
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(cloneValue(src.Index(i), visited))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(cloneValue(src.Index(i), visited))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		it := src.MapRange()
+		for it.Next() {
+			dst.SetMapIndex(cloneValue(it.Key(), visited), cloneValue(it.Value(), visited))
+		}
+		return dst
+
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if !src.Type().Field(i).IsExported() {
+				continue
+			}
+			dst.Field(i).Set(cloneValue(src.Field(i), visited))
+		}
+		return dst
+
+	default:
+		return src
+	}
+}
@@ -58,6 +58,8 @@ type iComponentManager interface {
 	RemoveComponent(name string)
 	// RemoveComponentById 使用组件Id删除组件（需要开启为实体组件分配唯一Id特性）
 	RemoveComponentById(id uid.Id)
+	// ReplaceComponent 使用名称替换组件，组件同名时替换首个组件，新组件继承旧组件的位置与Id，不可删除的组件不能替换，可以传入交接状态，未传入时使用旧组件实现的ComponentHandoff接口获取
+	ReplaceComponent(name string, component Component, handoff ...any) error
 
 	removeComponentByRef(comp Component)
 
@@ -182,6 +184,8 @@ func (entity *EntityBehavior) CountComponents() int {
 
 // AddComponent 添加组件，允许组件同名
 func (entity *EntityBehavior) AddComponent(name string, components ...Component) error {
+	entity.debugCheckDestroyed()
+
 	if len(components) <= 0 {
 		return fmt.Errorf("%w: %w: components is empty", ErrEC, exception.ErrArgs)
 	}
@@ -198,6 +202,12 @@ func (entity *EntityBehavior) AddComponent(name string, components ...Component)
 		}
 	}
 
+	for i := range components {
+		if err := entity.injectComponent(name, components[i]); err != nil {
+			return err
+		}
+	}
+
 	for i := range components {
 		entity.addComponent(name, components[i])
 	}
@@ -208,6 +218,8 @@ func (entity *EntityBehavior) AddComponent(name string, components ...Component)
 
 // RemoveComponent 使用名称删除组件，同名组件均会删除
 func (entity *EntityBehavior) RemoveComponent(name string) {
+	entity.debugCheckDestroyed()
+
 	compNode, ok := entity.getComponentNode(name)
 	if !ok {
 		return
@@ -234,7 +246,7 @@ func (entity *EntityBehavior) RemoveComponent(name string) {
 
 		if comp.GetState() >= ComponentState_Destroyed {
 			compNode.Escape()
-			entity.updateComponentNameIndex(comp.GetName())
+			entity.updateComponentIndex(comp)
 		}
 
 		return true
@@ -243,6 +255,8 @@ func (entity *EntityBehavior) RemoveComponent(name string) {
 
 // RemoveComponentById 使用组件Id删除组件（需要开启为实体组件分配唯一Id特性）
 func (entity *EntityBehavior) RemoveComponentById(id uid.Id) {
+	entity.debugCheckDestroyed()
+
 	compNode, ok := entity.getComponentNodeById(id)
 	if !ok {
 		return
@@ -264,13 +278,85 @@ func (entity *EntityBehavior) RemoveComponentById(id uid.Id) {
 
 	if comp.GetState() >= ComponentState_Destroyed {
 		compNode.Escape()
-		entity.updateComponentNameIndex(comp.GetName())
+		entity.updateComponentIndex(comp)
 	}
 }
 
+// ComponentHandoff 组件实现此接口，被替换时可以提供交接状态
+type ComponentHandoff interface {
+	// Handoff 获取交接状态
+	Handoff() any
+}
+
+// ComponentTakeover 组件实现此接口，替换其他组件时可以接收交接状态，在唤醒（Awake）前调用
+type ComponentTakeover interface {
+	// Takeover 接收交接状态
+	Takeover(state any)
+}
+
+// ReplaceComponent 使用名称替换组件，组件同名时替换首个组件，新组件继承旧组件的位置与Id，不可删除的组件不能替换，可以传入交接状态，未传入时使用旧组件实现的ComponentHandoff接口获取
+func (entity *EntityBehavior) ReplaceComponent(name string, component Component, handoff ...any) error {
+	entity.debugCheckDestroyed()
+
+	if component == nil {
+		return fmt.Errorf("%w: %w: component is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if component.GetState() != ComponentState_Birth {
+		return fmt.Errorf("%w: invalid component state %q", ErrEC, component.GetState())
+	}
+
+	compNode, ok := entity.getComponentNode(name)
+	if !ok {
+		return fmt.Errorf("%w: component %q not found", ErrEC, name)
+	}
+
+	oldComp := compNode.V
+
+	if !oldComp.GetRemovable() {
+		return fmt.Errorf("%w: component %q is not removable", ErrEC, name)
+	}
+
+	if oldComp.GetState() > ComponentState_Alive {
+		return fmt.Errorf("%w: invalid component %q state %q", ErrEC, name, oldComp.GetState())
+	}
+
+	var state any
+	if len(handoff) > 0 {
+		state = handoff[0]
+	} else if cb, ok := oldComp.(ComponentHandoff); ok {
+		state = cb.Handoff()
+	}
+
+	if err := entity.injectComponent(name, component); err != nil {
+		return err
+	}
+
+	component.init(name, entity.opts.InstanceFace.Iface, component)
+	component.setRemovable(oldComp.GetRemovable())
+	component.setId(oldComp.GetId())
+
+	if cb, ok := component.(ComponentTakeover); ok {
+		cb.Takeover(state)
+	}
+
+	oldComp.setState(ComponentState_Detach)
+
+	component.setNode(entity.components.InsertAfter(component, compNode))
+	compNode.Escape()
+	entity.updateComponentIndex(oldComp)
+	entity.updateComponentIndex(component)
+
+	component.setState(ComponentState_Attach)
+
+	_EmitEventComponentManagerReplaceComponent(entity, entity.opts.InstanceFace.Iface, oldComp, component)
+
+	return nil
+}
+
 // EventComponentManagerAddComponents 事件：实体的组件管理器添加组件
 func (entity *EntityBehavior) EventComponentManagerAddComponents() event.IEvent {
-	return entity.entityComponentManagerEventTab.EventComponentManagerRemoveComponent()
+	return entity.entityComponentManagerEventTab.EventComponentManagerAddComponents()
 }
 
 // EventComponentManagerRemoveComponent 事件：实体的组件管理器删除组件
@@ -283,7 +369,14 @@ func (entity *EntityBehavior) EventComponentManagerFirstTouchComponent() event.I
 	return entity.entityComponentManagerEventTab.EventComponentManagerFirstTouchComponent()
 }
 
+// EventComponentManagerReplaceComponent 事件：实体的组件管理器替换组件
+func (entity *EntityBehavior) EventComponentManagerReplaceComponent() event.IEvent {
+	return entity.entityComponentManagerEventTab.EventComponentManagerReplaceComponent()
+}
+
 func (entity *EntityBehavior) removeComponentByRef(comp Component) {
+	entity.debugCheckDestroyed()
+
 	compNode, ok := entity.getComponentNodeByRef(comp)
 	if !ok {
 		return
@@ -303,13 +396,25 @@ func (entity *EntityBehavior) removeComponentByRef(comp Component) {
 
 	if comp.GetState() >= ComponentState_Destroyed {
 		compNode.Escape()
-		entity.updateComponentNameIndex(comp.GetName())
+		entity.updateComponentIndex(comp)
+	}
+}
+
+func (entity *EntityBehavior) injectComponent(name string, component Component) error {
+	if entity.componentInjector == nil {
+		return nil
 	}
+	if err := entity.componentInjector.UnsafeCall(component); err != nil {
+		return fmt.Errorf("%w: component %q inject failed, %w", ErrEC, name, err)
+	}
+	return nil
 }
 
 func (entity *EntityBehavior) addComponent(name string, component Component) {
 	component.init(name, entity.opts.InstanceFace.Iface, component)
 
+	var compNode *generic.Node[Component]
+
 	if at, ok := entity.getComponentNode(name); ok {
 		entity.components.TraversalAt(func(compNode *generic.Node[Component]) bool {
 			if compNode.V.GetName() == name {
@@ -319,16 +424,42 @@ func (entity *EntityBehavior) addComponent(name string, component Component) {
 			return false
 		}, at)
 
-		entity.components.InsertAfter(component, at)
+		compNode = entity.components.InsertAfter(component, at)
 
 	} else {
-		compNode := entity.components.PushBack(component)
+		compNode = entity.components.PushBack(component)
 
 		if entity.opts.ComponentNameIndexing {
 			entity.componentNameIndex.Add(name, compNode)
 		}
 	}
 
+	component.setNode(compNode)
+
+	if entity.opts.ComponentIdIndexing && !component.GetId().IsNil() {
+		if _, ok := entity.componentIdIndex[component.GetId()]; ok {
+			entity.updateComponentIdIndex(component.GetId())
+		} else {
+			if entity.componentIdIndex == nil {
+				entity.componentIdIndex = map[uid.Id]*generic.Node[Component]{}
+			}
+			entity.componentIdIndex[component.GetId()] = compNode
+		}
+	}
+
+	if entity.opts.ComponentPTIndexing {
+		prototype := component.GetBuiltin().PT.Prototype()
+
+		if _, ok := entity.componentPTIndex[prototype]; ok {
+			entity.updateComponentPTIndex(prototype)
+		} else {
+			if entity.componentPTIndex == nil {
+				entity.componentPTIndex = map[string]*generic.Node[Component]{}
+			}
+			entity.componentPTIndex[prototype] = compNode
+		}
+	}
+
 	component.setState(ComponentState_Attach)
 }
 
@@ -337,52 +468,48 @@ func (entity *EntityBehavior) getComponentNode(name string) (*generic.Node[Compo
 		return entity.componentNameIndex.Get(name)
 	}
 
-	var compNode *generic.Node[Component]
-
-	entity.components.Traversal(func(node *generic.Node[Component]) bool {
-		if node.V.GetName() == name {
-			compNode = node
-			return false
-		}
-		return true
+	return entity.findComponentNode(func(comp Component) bool {
+		return comp.GetName() == name
 	})
-
-	return compNode, compNode != nil
 }
 
 func (entity *EntityBehavior) getComponentNodeById(id uid.Id) (*generic.Node[Component], bool) {
-	var compNode *generic.Node[Component]
+	if entity.opts.ComponentIdIndexing {
+		compNode, ok := entity.componentIdIndex[id]
+		return compNode, ok
+	}
 
-	entity.components.Traversal(func(node *generic.Node[Component]) bool {
-		if node.V.GetId() == id {
-			compNode = node
-			return false
-		}
-		return true
+	return entity.findComponentNode(func(comp Component) bool {
+		return comp.GetId() == id
 	})
-
-	return compNode, compNode != nil
 }
 
 func (entity *EntityBehavior) getComponentNodeByPT(prototype string) (*generic.Node[Component], bool) {
-	var compNode *generic.Node[Component]
+	if entity.opts.ComponentPTIndexing {
+		compNode, ok := entity.componentPTIndex[prototype]
+		return compNode, ok
+	}
 
-	entity.components.Traversal(func(node *generic.Node[Component]) bool {
-		if node.V.GetBuiltin().PT.Prototype() == prototype {
-			compNode = node
-			return false
-		}
-		return true
+	return entity.findComponentNode(func(comp Component) bool {
+		return comp.GetBuiltin().PT.Prototype() == prototype
 	})
-
-	return compNode, compNode != nil
 }
 
 func (entity *EntityBehavior) getComponentNodeByRef(comp Component) (*generic.Node[Component], bool) {
+	if compNode := comp.getNode(); compNode != nil && !compNode.Escaped() && compNode.V == comp {
+		return compNode, true
+	}
+
+	return entity.findComponentNode(func(node Component) bool {
+		return node == comp
+	})
+}
+
+func (entity *EntityBehavior) findComponentNode(fun func(comp Component) bool) (*generic.Node[Component], bool) {
 	var compNode *generic.Node[Component]
 
 	entity.components.Traversal(func(node *generic.Node[Component]) bool {
-		if node.V == comp {
+		if fun(node.V) {
 			compNode = node
 			return false
 		}
@@ -404,14 +531,85 @@ func (entity *EntityBehavior) touchComponent(comp Component) Component {
 	return comp
 }
 
+func (entity *EntityBehavior) updateComponentIndex(comp Component) {
+	entity.updateComponentNameIndex(comp.GetName())
+	entity.updateComponentIdIndex(comp.GetId())
+	entity.updateComponentPTIndex(comp.GetBuiltin().PT.Prototype())
+}
+
 func (entity *EntityBehavior) updateComponentNameIndex(name string) {
 	if !entity.opts.ComponentNameIndexing {
 		return
 	}
 
-	if compNode, ok := entity.getComponentNode(name); ok {
+	if compNode, ok := entity.findComponentNode(func(comp Component) bool { return comp.GetName() == name }); ok {
 		entity.componentNameIndex.Add(name, compNode)
 	} else {
 		entity.componentNameIndex.Delete(name)
 	}
 }
+
+func (entity *EntityBehavior) updateComponentIdIndex(id uid.Id) {
+	if !entity.opts.ComponentIdIndexing {
+		return
+	}
+
+	if entity.componentIdIndex == nil {
+		entity.componentIdIndex = map[uid.Id]*generic.Node[Component]{}
+	}
+
+	if compNode, ok := entity.findComponentNode(func(comp Component) bool { return comp.GetId() == id }); ok {
+		entity.componentIdIndex[id] = compNode
+	} else {
+		delete(entity.componentIdIndex, id)
+	}
+}
+
+func (entity *EntityBehavior) changeComponentId(comp Component, old uid.Id) {
+	if !entity.opts.ComponentIdIndexing {
+		return
+	}
+
+	if !old.IsNil() {
+		if compNode, ok := entity.componentIdIndex[old]; ok && compNode.V == comp {
+			entity.updateComponentIdIndex(old)
+		}
+	}
+
+	id := comp.GetId()
+	if id.IsNil() {
+		return
+	}
+
+	compNode := comp.getNode()
+	if compNode == nil || compNode.Escaped() || compNode.V != comp {
+		return
+	}
+
+	// 索引中已有其他相同Id的组件时（组件共用实体Id），需要查找链表中首个组件，否则直接索引组件所在节点
+	if indexed, ok := entity.componentIdIndex[id]; ok && indexed != compNode {
+		entity.updateComponentIdIndex(id)
+		return
+	}
+
+	if entity.componentIdIndex == nil {
+		entity.componentIdIndex = map[uid.Id]*generic.Node[Component]{}
+	}
+	entity.componentIdIndex[id] = compNode
+}
+
+func (entity *EntityBehavior) updateComponentPTIndex(prototype string) {
+	if !entity.opts.ComponentPTIndexing {
+		return
+	}
+
+	if entity.componentPTIndex == nil {
+		entity.componentPTIndex = map[string]*generic.Node[Component]{}
+	}
+
+	if compNode, ok := entity.findComponentNode(func(comp Component) bool { return comp.GetBuiltin().PT.Prototype() == prototype }); ok {
+		entity.componentPTIndex[prototype] = compNode
+	} else {
+		delete(entity.componentPTIndex, prototype)
+	}
+}
@@ -162,3 +162,49 @@ type EventComponentManagerFirstTouchComponentHandler func(entity Entity, compone
 func (h EventComponentManagerFirstTouchComponentHandler) OnComponentManagerFirstTouchComponent(entity Entity, component Component) {
 	h(entity, component)
 }
+
+type iAutoEventComponentManagerReplaceComponent interface {
+	EventComponentManagerReplaceComponent() event.IEvent
+}
+
+func BindEventComponentManagerReplaceComponent(auto iAutoEventComponentManagerReplaceComponent, subscriber EventComponentManagerReplaceComponent, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventComponentManagerReplaceComponent](auto.EventComponentManagerReplaceComponent(), subscriber, priority...)
+}
+
+func _EmitEventComponentManagerReplaceComponent(auto iAutoEventComponentManagerReplaceComponent, entity Entity, oldComponent, newComponent Component) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventComponentManagerReplaceComponent()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventComponentManagerReplaceComponent](subscriber).OnComponentManagerReplaceComponent(entity, oldComponent, newComponent)
+		return true
+	})
+}
+
+func _EmitEventComponentManagerReplaceComponentWithInterrupt(auto iAutoEventComponentManagerReplaceComponent, interrupt func(entity Entity, oldComponent, newComponent Component) bool, entity Entity, oldComponent, newComponent Component) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventComponentManagerReplaceComponent()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entity, oldComponent, newComponent) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventComponentManagerReplaceComponent](subscriber).OnComponentManagerReplaceComponent(entity, oldComponent, newComponent)
+		return true
+	})
+}
+
+func HandleEventComponentManagerReplaceComponent(fun func(entity Entity, oldComponent, newComponent Component)) EventComponentManagerReplaceComponentHandler {
+	return EventComponentManagerReplaceComponentHandler(fun)
+}
+
+type EventComponentManagerReplaceComponentHandler func(entity Entity, oldComponent, newComponent Component)
+
+func (h EventComponentManagerReplaceComponentHandler) OnComponentManagerReplaceComponent(entity Entity, oldComponent, newComponent Component) {
+	h(entity, oldComponent, newComponent)
+}
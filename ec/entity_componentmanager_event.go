@@ -38,3 +38,9 @@ type EventComponentManagerRemoveComponent interface {
 type EventComponentManagerFirstTouchComponent interface {
 	OnComponentManagerFirstTouchComponent(entity Entity, component Component)
 }
+
+// EventComponentManagerReplaceComponent 事件：实体的组件管理器替换组件
+// +event-gen:export=0
+type EventComponentManagerReplaceComponent interface {
+	OnComponentManagerReplaceComponent(entity Entity, oldComponent, newComponent Component)
+}
@@ -29,6 +29,7 @@ type IEntityComponentManagerEventTab interface {
 	EventComponentManagerAddComponents() event.IEvent
 	EventComponentManagerRemoveComponent() event.IEvent
 	EventComponentManagerFirstTouchComponent() event.IEvent
+	EventComponentManagerReplaceComponent() event.IEvent
 }
 
 var (
@@ -36,14 +37,16 @@ var (
 	EventComponentManagerAddComponentsId = _entityComponentManagerEventTabId + 0
 	EventComponentManagerRemoveComponentId = _entityComponentManagerEventTabId + 1
 	EventComponentManagerFirstTouchComponentId = _entityComponentManagerEventTabId + 2
+	EventComponentManagerReplaceComponentId = _entityComponentManagerEventTabId + 3
 )
 
-type entityComponentManagerEventTab [3]event.Event
+type entityComponentManagerEventTab [4]event.Event
 
 func (eventTab *entityComponentManagerEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
 	(*eventTab)[0].Init(autoRecover, reportError, recursion)
 	(*eventTab)[1].Init(autoRecover, reportError, recursion)
 	(*eventTab)[2].Init(autoRecover, reportError, recursion)
+	(*eventTab)[3].Init(autoRecover, reportError, recursion)
 }
 
 func (eventTab *entityComponentManagerEventTab) Open() {
@@ -90,3 +93,7 @@ func (eventTab *entityComponentManagerEventTab) EventComponentManagerRemoveCompo
 func (eventTab *entityComponentManagerEventTab) EventComponentManagerFirstTouchComponent() event.IEvent {
 	return &(*eventTab)[2]
 }
+
+func (eventTab *entityComponentManagerEventTab) EventComponentManagerReplaceComponent() event.IEvent {
+	return &(*eventTab)[3]
+}
@@ -0,0 +1,126 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/types"
+	"git.golaxy.org/core/utils/uid"
+)
+
+// GetComponentT 使用名称查询组件，并转换为指定类型，组件同名时，返回首个组件
+func GetComponentT[T any](entity Entity, name string) (T, bool) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+	comp, ok := entity.GetComponent(name).(T)
+	return comp, ok
+}
+
+// RequireComponentT 使用名称查询组件，并转换为指定类型，组件不存在或类型不匹配时panic
+func RequireComponentT[T any](entity Entity, name string) T {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	comp := entity.GetComponent(name)
+	if comp == nil {
+		exception.Panicf("%w: entity %q component %q not found", ErrEC, entity.GetId(), name)
+	}
+
+	v, ok := comp.(T)
+	if !ok {
+		exception.Panicf("%w: entity %q component %q type %q can't convert to %q", ErrEC, entity.GetId(), name, types.FullName(comp), types.FullNameT[T]())
+	}
+
+	return v
+}
+
+// RangeComponentsT 遍历所有可以转换为指定类型的组件，指定类型可以是组件实现的接口
+func RangeComponentsT[T any](entity Entity, fun generic.Func1[T, bool]) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	entity.RangeComponents(func(comp Component) bool {
+		v, ok := comp.(T)
+		if !ok {
+			return true
+		}
+		return fun.UnsafeCall(v)
+	})
+}
+
+// MakeComponentRef 创建组件引用，缓存组件查询结果，实体组件列表变化后重新查询
+func MakeComponentRef[T any](entity Entity, name string) ComponentRef[T] {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+	return ComponentRef[T]{
+		entity:  entity,
+		name:    name,
+		version: -1,
+	}
+}
+
+// ComponentRef 组件引用，缓存组件查询结果，实体组件列表变化后重新查询
+type ComponentRef[T any] struct {
+	entity   Entity
+	entityId uid.Id
+	name     string
+	version  int64
+	comp     T
+	ok       bool
+}
+
+// Get 获取组件
+func (ref *ComponentRef[T]) Get() (T, bool) {
+	if ref.entity == nil {
+		return types.ZeroT[T](), false
+	}
+
+	if ref.version != ref.entity.getVersion() || ref.entityId != ref.entity.GetId() || (ref.ok && any(ref.comp).(Component).GetState() >= ComponentState_Destroyed) {
+		ref.comp, ref.ok = GetComponentT[T](ref.entity, ref.name)
+		ref.version = ref.entity.getVersion()
+		ref.entityId = ref.entity.GetId()
+	}
+
+	return ref.comp, ref.ok
+}
+
+// Require 获取组件，组件不存在或类型不匹配时panic
+func (ref *ComponentRef[T]) Require() T {
+	comp, ok := ref.Get()
+	if !ok {
+		exception.Panicf("%w: entity %q component %q not found or type mismatch", ErrEC, ref.entity.GetId(), ref.name)
+	}
+	return comp
+}
+
+// GetEntity 获取实体
+func (ref *ComponentRef[T]) GetEntity() Entity {
+	return ref.entity
+}
+
+// GetName 获取组件名称
+func (ref *ComponentRef[T]) GetName() string {
+	return ref.name
+}
@@ -0,0 +1,101 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec_test
+
+import (
+	"fmt"
+	"testing"
+
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/ec/pt"
+	"git.golaxy.org/core/utils/uid"
+)
+
+const benchComponentCount = 32
+
+type benchFillerComp struct {
+	ec.ComponentBehavior
+}
+
+type benchTargetComp struct {
+	ec.ComponentBehavior
+}
+
+func newBenchEntity(b *testing.B, indexing bool) ec.Entity {
+	comps := make([]any, 0, benchComponentCount)
+	for range benchComponentCount - 1 {
+		comps = append(comps, &benchFillerComp{})
+	}
+	comps = append(comps, &benchTargetComp{})
+
+	uniqueID := true
+
+	entityPT := pt.NewEntityLib(pt.NewComponentLib()).Declare(pt.EntityAttribute{
+		Prototype:           fmt.Sprintf("bench_%t", indexing),
+		ComponentIdIndexing: &indexing,
+		ComponentPTIndexing: &indexing,
+		ComponentUniqueID:   &uniqueID,
+	}, comps...)
+
+	entity := entityPT.Construct()
+	ec.UnsafeEntity(entity).SetId(uid.New())
+	entity.RangeComponents(func(comp ec.Component) bool {
+		ec.UnsafeComponent(comp).SetId(uid.New())
+		return true
+	})
+
+	if entity.CountComponents() != benchComponentCount {
+		b.Fatalf("unexpected component count %d", entity.CountComponents())
+	}
+
+	return entity
+}
+
+func BenchmarkGetComponentByPT(b *testing.B) {
+	for _, indexing := range []bool{false, true} {
+		entity := newBenchEntity(b, indexing)
+		comps := entity.GetComponents()
+		prototype := comps[len(comps)-1].GetBuiltin().PT.Prototype()
+
+		b.Run(fmt.Sprintf("indexing=%t", indexing), func(b *testing.B) {
+			for range b.N {
+				if entity.GetComponentByPT(prototype) == nil {
+					b.Fatal("component not found")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetComponentById(b *testing.B) {
+	for _, indexing := range []bool{false, true} {
+		entity := newBenchEntity(b, indexing)
+		comps := entity.GetComponents()
+		id := comps[len(comps)-1].GetId()
+
+		b.Run(fmt.Sprintf("indexing=%t", indexing), func(b *testing.B) {
+			for range b.N {
+				if entity.GetComponentById(id) == nil {
+					b.Fatal("component not found")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"slices"
+)
+
+// GetDirtyComponents 获取本帧属性（Property）改变过的组件
+func (entity *EntityBehavior) GetDirtyComponents() []Component {
+	var comps []Component
+	for _, comp := range entity.dirtyComponents {
+		if comp.GetEntity() != entity.opts.InstanceFace.Iface || comp.GetState() > ComponentState_Alive {
+			continue
+		}
+		comps = append(comps, comp)
+	}
+	return comps
+}
+
+// IsDirty 本帧是否有组件属性（Property）改变过
+func (entity *EntityBehavior) IsDirty() bool {
+	return len(entity.dirtyComponents) > 0
+}
+
+func (entity *EntityBehavior) markComponentDirty(comp Component) {
+	if entity.state > EntityState_Alive || slices.Contains(entity.dirtyComponents, comp) {
+		return
+	}
+
+	entity.dirtyComponents = append(entity.dirtyComponents, comp)
+
+	if len(entity.dirtyComponents) == 1 {
+		_EmitEventEntityDirty(entity, entity.opts.InstanceFace.Iface)
+	}
+}
+
+func (entity *EntityBehavior) clearDirty() {
+	for _, comp := range entity.dirtyComponents {
+		comp.clearDirty()
+	}
+	clear(entity.dirtyComponents)
+	entity.dirtyComponents = entity.dirtyComponents[:0]
+}
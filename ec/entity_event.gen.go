@@ -23,6 +23,7 @@ package ec
 
 import (
 	event "git.golaxy.org/core/event"
+	"time"
 )
 
 type iAutoEventEntityDestroySelf interface {
@@ -70,3 +71,141 @@ type EventEntityDestroySelfHandler func(entity Entity)
 func (h EventEntityDestroySelfHandler) OnEntityDestroySelf(entity Entity) {
 	h(entity)
 }
+
+type iAutoEventEntityDestroyDeferred interface {
+	EventEntityDestroyDeferred() event.IEvent
+}
+
+func BindEventEntityDestroyDeferred(auto iAutoEventEntityDestroyDeferred, subscriber EventEntityDestroyDeferred, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityDestroyDeferred](auto.EventEntityDestroyDeferred(), subscriber, priority...)
+}
+
+func _EmitEventEntityDestroyDeferred(auto iAutoEventEntityDestroyDeferred, entity Entity, delay time.Duration) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDestroyDeferred()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityDestroyDeferred](subscriber).OnEntityDestroyDeferred(entity, delay)
+		return true
+	})
+}
+
+func _EmitEventEntityDestroyDeferredWithInterrupt(auto iAutoEventEntityDestroyDeferred, interrupt func(entity Entity, delay time.Duration) bool, entity Entity, delay time.Duration) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDestroyDeferred()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entity, delay) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityDestroyDeferred](subscriber).OnEntityDestroyDeferred(entity, delay)
+		return true
+	})
+}
+
+func HandleEventEntityDestroyDeferred(fun func(entity Entity, delay time.Duration)) EventEntityDestroyDeferredHandler {
+	return EventEntityDestroyDeferredHandler(fun)
+}
+
+type EventEntityDestroyDeferredHandler func(entity Entity, delay time.Duration)
+
+func (h EventEntityDestroyDeferredHandler) OnEntityDestroyDeferred(entity Entity, delay time.Duration) {
+	h(entity, delay)
+}
+
+type iAutoEventEntityDirty interface {
+	EventEntityDirty() event.IEvent
+}
+
+func BindEventEntityDirty(auto iAutoEventEntityDirty, subscriber EventEntityDirty, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityDirty](auto.EventEntityDirty(), subscriber, priority...)
+}
+
+func _EmitEventEntityDirty(auto iAutoEventEntityDirty, entity Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDirty()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityDirty](subscriber).OnEntityDirty(entity)
+		return true
+	})
+}
+
+func _EmitEventEntityDirtyWithInterrupt(auto iAutoEventEntityDirty, interrupt func(entity Entity) bool, entity Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDirty()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entity) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityDirty](subscriber).OnEntityDirty(entity)
+		return true
+	})
+}
+
+func HandleEventEntityDirty(fun func(entity Entity)) EventEntityDirtyHandler {
+	return EventEntityDirtyHandler(fun)
+}
+
+type EventEntityDirtyHandler func(entity Entity)
+
+func (h EventEntityDirtyHandler) OnEntityDirty(entity Entity) {
+	h(entity)
+}
+
+type iAutoEventEntityActiveChanged interface {
+	EventEntityActiveChanged() event.IEvent
+}
+
+func BindEventEntityActiveChanged(auto iAutoEventEntityActiveChanged, subscriber EventEntityActiveChanged, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityActiveChanged](auto.EventEntityActiveChanged(), subscriber, priority...)
+}
+
+func _EmitEventEntityActiveChanged(auto iAutoEventEntityActiveChanged, entity Entity, active bool) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityActiveChanged()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityActiveChanged](subscriber).OnEntityActiveChanged(entity, active)
+		return true
+	})
+}
+
+func _EmitEventEntityActiveChangedWithInterrupt(auto iAutoEventEntityActiveChanged, interrupt func(entity Entity, active bool) bool, entity Entity, active bool) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityActiveChanged()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entity, active) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityActiveChanged](subscriber).OnEntityActiveChanged(entity, active)
+		return true
+	})
+}
+
+func HandleEventEntityActiveChanged(fun func(entity Entity, active bool)) EventEntityActiveChangedHandler {
+	return EventEntityActiveChangedHandler(fun)
+}
+
+type EventEntityActiveChangedHandler func(entity Entity, active bool)
+
+func (h EventEntityActiveChangedHandler) OnEntityActiveChanged(entity Entity, active bool) {
+	h(entity, active)
+}
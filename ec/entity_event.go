@@ -21,9 +21,32 @@
 //go:generate go run git.golaxy.org/core/event/eventc eventtab --name=entityEventTab
 package ec
 
+import "time"
+
 // EventEntityDestroySelf 事件：实体销毁自身
 // +event-gen:export=0
 // +event-tab-gen:recursion=discard
 type EventEntityDestroySelf interface {
 	OnEntityDestroySelf(entity Entity)
 }
+
+// EventEntityDestroyDeferred 事件：实体延迟销毁自身
+// +event-gen:export=0
+// +event-tab-gen:recursion=discard
+type EventEntityDestroyDeferred interface {
+	OnEntityDestroyDeferred(entity Entity, delay time.Duration)
+}
+
+// EventEntityDirty 事件：实体的组件属性在本帧内首次改变，实体成为脏实体
+// +event-gen:export=0
+// +event-tab-gen:recursion=discard
+type EventEntityDirty interface {
+	OnEntityDirty(entity Entity)
+}
+
+// EventEntityActiveChanged 事件：实体在实体树中的激活状态改变
+// +event-gen:export=0
+// +event-tab-gen:recursion=deepest
+type EventEntityActiveChanged interface {
+	OnEntityActiveChanged(entity Entity, active bool)
+}
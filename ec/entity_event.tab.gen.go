@@ -27,17 +27,26 @@ import (
 
 type IEntityEventTab interface {
 	EventEntityDestroySelf() event.IEvent
+	EventEntityDestroyDeferred() event.IEvent
+	EventEntityDirty() event.IEvent
+	EventEntityActiveChanged() event.IEvent
 }
 
 var (
 	_entityEventTabId = event.DeclareEventTabIdT[entityEventTab]()
 	EventEntityDestroySelfId = _entityEventTabId + 0
+	EventEntityDestroyDeferredId = _entityEventTabId + 1
+	EventEntityDirtyId = _entityEventTabId + 2
+	EventEntityActiveChangedId = _entityEventTabId + 3
 )
 
-type entityEventTab [1]event.Event
+type entityEventTab [4]event.Event
 
 func (eventTab *entityEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
 	(*eventTab)[0].Init(autoRecover, reportError, event.EventRecursion_Discard)
+	(*eventTab)[1].Init(autoRecover, reportError, event.EventRecursion_Discard)
+	(*eventTab)[2].Init(autoRecover, reportError, event.EventRecursion_Discard)
+	(*eventTab)[3].Init(autoRecover, reportError, event.EventRecursion_Deepest)
 }
 
 func (eventTab *entityEventTab) Open() {
@@ -76,3 +85,15 @@ func (eventTab *entityEventTab) Event(id uint64) event.IEvent {
 func (eventTab *entityEventTab) EventEntityDestroySelf() event.IEvent {
 	return &(*eventTab)[0]
 }
+
+func (eventTab *entityEventTab) EventEntityDestroyDeferred() event.IEvent {
+	return &(*eventTab)[1]
+}
+
+func (eventTab *entityEventTab) EventEntityDirty() event.IEvent {
+	return &(*eventTab)[2]
+}
+
+func (eventTab *entityEventTab) EventEntityActiveChanged() event.IEvent {
+	return &(*eventTab)[3]
+}
@@ -32,6 +32,8 @@ type EntityOptions struct {
 	Scope                      Scope              // 可访问作用域
 	PersistId                  uid.Id             // 实体持久化Id
 	ComponentNameIndexing      bool               // 是否开启组件名称索引
+	ComponentIdIndexing        bool               // 是否开启组件Id索引
+	ComponentPTIndexing        bool               // 是否开启组件原型索引
 	ComponentAwakeOnFirstTouch bool               // 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 	ComponentUniqueID          bool               // 是否为实体组件分配唯一Id
 	Meta                       meta.Meta          // Meta信息
@@ -48,6 +50,8 @@ func (_Option) Default() option.Setting[EntityOptions] {
 		With.Scope(Scope_Global)(o)
 		With.PersistId(uid.Nil)(o)
 		With.ComponentNameIndexing(true)(o)
+		With.ComponentIdIndexing(false)(o)
+		With.ComponentPTIndexing(false)(o)
 		With.ComponentAwakeOnFirstTouch(false)(o)
 		With.ComponentUniqueID(false)(o)
 		With.Meta(nil)(o)
@@ -82,6 +86,20 @@ func (_Option) ComponentNameIndexing(b bool) option.Setting[EntityOptions] {
 	}
 }
 
+// ComponentIdIndexing 是否开启组件Id索引
+func (_Option) ComponentIdIndexing(b bool) option.Setting[EntityOptions] {
+	return func(o *EntityOptions) {
+		o.ComponentIdIndexing = b
+	}
+}
+
+// ComponentPTIndexing 是否开启组件原型索引
+func (_Option) ComponentPTIndexing(b bool) option.Setting[EntityOptions] {
+	return func(o *EntityOptions) {
+		o.ComponentPTIndexing = b
+	}
+}
+
 // ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 func (_Option) ComponentAwakeOnFirstTouch(b bool) option.Setting[EntityOptions] {
 	return func(o *EntityOptions) {
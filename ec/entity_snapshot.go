@@ -0,0 +1,262 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/meta"
+	"git.golaxy.org/core/utils/uid"
+	"reflect"
+	"strings"
+)
+
+// ComponentStateMarshaler 组件实现此接口，可以自定义快照中的组件状态
+type ComponentStateMarshaler interface {
+	// MarshalState 序列化组件状态
+	MarshalState() ([]byte, error)
+}
+
+// ComponentStateUnmarshaler 组件实现此接口，可以自定义从快照中恢复组件状态
+type ComponentStateUnmarshaler interface {
+	// UnmarshalState 反序列化组件状态
+	UnmarshalState(data []byte) error
+}
+
+// EntitySnapshot 实体快照，支持JSON与二进制序列化
+type EntitySnapshot struct {
+	Prototype  string              `json:"prototype"`            // 实体原型名称
+	PersistId  uid.Id              `json:"persist_id"`           // 实体持久化Id
+	Active     bool                `json:"active"`               // 实体自身是否激活
+	Meta       meta.Meta           `json:"meta,omitempty"`       // Meta信息
+	Components []ComponentSnapshot `json:"components,omitempty"` // 组件快照
+	Children   []*EntitySnapshot   `json:"children,omitempty"`   // 子实体快照
+}
+
+type _EntitySnapshot EntitySnapshot
+
+// ComponentSnapshot 组件快照
+type ComponentSnapshot struct {
+	Name      string `json:"name"`            // 组件名称
+	Prototype string `json:"prototype"`       // 组件原型名称
+	Id        uid.Id `json:"id"`              // 组件Id
+	Enable    bool   `json:"enable"`          // 组件是否启用
+	State     []byte `json:"state,omitempty"` // 组件状态
+}
+
+// MarshalBinary 二进制序列化
+func (snapshot *EntitySnapshot) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode((*_EntitySnapshot)(snapshot)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEC, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary 二进制反序列化
+func (snapshot *EntitySnapshot) UnmarshalBinary(data []byte) error {
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode((*_EntitySnapshot)(snapshot)); err != nil {
+		return fmt.Errorf("%w: %w", ErrEC, err)
+	}
+	return nil
+}
+
+// TakeEntitySnapshot 获取实体快照，不包含子实体，组件状态来自组件实现的ComponentStateMarshaler接口，或标记了`golaxy:"snapshot"`的导出字段
+func TakeEntitySnapshot(entity Entity) (*EntitySnapshot, error) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	snapshot := &EntitySnapshot{
+		Prototype: entity.GetPT().Prototype(),
+		PersistId: entity.GetId(),
+		Active:    entity.GetActive(),
+		Meta:      entity.GetMeta().Clone(),
+	}
+
+	var err error
+
+	entity.RangeComponents(func(comp Component) bool {
+		var state []byte
+
+		state, err = marshalComponentState(comp)
+		if err != nil {
+			err = fmt.Errorf("%w: marshal entity %q component %q state failed, %w", ErrEC, entity.GetId(), comp.GetName(), err)
+			return false
+		}
+
+		snapshot.Components = append(snapshot.Components, ComponentSnapshot{
+			Name:      comp.GetName(),
+			Prototype: comp.GetBuiltin().PT.Prototype(),
+			Id:        comp.GetId(),
+			Enable:    comp.GetEnable(),
+			State:     state,
+		})
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// ApplyEntitySnapshot 将快照应用至实体，不包含子实体，只能在实体唤醒（Awake）前调用，快照中的组件按名称与原型依次匹配实体中的组件
+func ApplyEntitySnapshot(entity Entity, snapshot *EntitySnapshot) error {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if snapshot == nil {
+		exception.Panicf("%w: %w: snapshot is nil", ErrEC, exception.ErrArgs)
+	}
+
+	if entity.GetState() > EntityState_Enter {
+		return fmt.Errorf("%w: invalid entity %q state %q", ErrEC, entity.GetId(), entity.GetState())
+	}
+
+	if entity.GetPT().Prototype() != snapshot.Prototype {
+		return fmt.Errorf("%w: entity prototype %q mismatch snapshot prototype %q", ErrEC, entity.GetPT().Prototype(), snapshot.Prototype)
+	}
+
+	comps := entity.GetComponents()
+
+	for i := range snapshot.Components {
+		compSnapshot := &snapshot.Components[i]
+
+		idx := -1
+		for j, comp := range comps {
+			if comp != nil && comp.GetName() == compSnapshot.Name && comp.GetBuiltin().PT.Prototype() == compSnapshot.Prototype {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("%w: entity %q component %q(%q) not found", ErrEC, entity.GetId(), compSnapshot.Name, compSnapshot.Prototype)
+		}
+
+		comp := comps[idx]
+		comps[idx] = nil
+
+		if err := unmarshalComponentState(comp, compSnapshot.State); err != nil {
+			return fmt.Errorf("%w: unmarshal entity %q component %q state failed, %w", ErrEC, entity.GetId(), comp.GetName(), err)
+		}
+
+		if !compSnapshot.Id.IsNil() {
+			comp.setId(compSnapshot.Id)
+		}
+		comp.SetEnable(compSnapshot.Enable)
+	}
+
+	entity.SetActive(snapshot.Active)
+
+	return nil
+}
+
+func marshalComponentState(comp Component) ([]byte, error) {
+	if marshaler, ok := comp.(ComponentStateMarshaler); ok {
+		return marshaler.MarshalState()
+	}
+
+	fields := snapshotFields(comp)
+	if len(fields) <= 0 {
+		return nil, nil
+	}
+
+	state := make(map[string]json.RawMessage, len(fields))
+
+	for name, field := range fields {
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+		state[name] = data
+	}
+
+	return json.Marshal(state)
+}
+
+func unmarshalComponentState(comp Component, data []byte) error {
+	if unmarshaler, ok := comp.(ComponentStateUnmarshaler); ok {
+		return unmarshaler.UnmarshalState(data)
+	}
+
+	if len(data) <= 0 {
+		return nil
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	for name, field := range snapshotFields(comp) {
+		fieldData, ok := state[name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(fieldData, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func snapshotFields(comp Component) map[string]reflect.Value {
+	compRV := reflect.ValueOf(comp)
+	for compRV.Kind() == reflect.Pointer {
+		compRV = compRV.Elem()
+	}
+
+	if compRV.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields map[string]reflect.Value
+
+	for i := 0; i < compRV.NumField(); i++ {
+		field := compRV.Type().Field(i)
+
+		if !field.IsExported() || !hasTagOption(field.Tag.Get("golaxy"), "snapshot") {
+			continue
+		}
+
+		if fields == nil {
+			fields = map[string]reflect.Value{}
+		}
+		fields[field.Name] = compRV.Field(i)
+	}
+
+	return fields
+}
+
+func hasTagOption(tag, option string) bool {
+	for _, opt := range strings.Split(tag, ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
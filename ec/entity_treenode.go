@@ -71,6 +71,7 @@ func (entity *EntityBehavior) setTreeNodeState(state TreeNodeState) {
 
 func (entity *EntityBehavior) setTreeNodeParent(parent Entity) {
 	entity.treeNodeParent = parent
+	entity.updateActiveInHierarchy()
 }
 
 func (entity *EntityBehavior) enterParentNode() {
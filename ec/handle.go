@@ -0,0 +1,117 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"fmt"
+	"git.golaxy.org/core/utils/uid"
+	"sync/atomic"
+)
+
+var lastGeneration atomic.Int64
+
+func nextGeneration() int64 {
+	return lastGeneration.Add(1)
+}
+
+// EntityResolver 实体查询器，运行时的实体管理器实现了此接口
+type EntityResolver interface {
+	// GetEntity 查询实体
+	GetEntity(id uid.Id) (Entity, bool)
+}
+
+// EntityHandle 实体句柄，由实体Id与实体代数组成，实体每次初始化（包括从对象池中复用）都会分配新的代数，用于安全的跨帧弱引用实体
+type EntityHandle struct {
+	Id         uid.Id // 实体Id
+	Generation int64  // 实体代数
+}
+
+// IsNil 是否为空
+func (h EntityHandle) IsNil() bool {
+	return h.Id.IsNil() || h.Generation == 0
+}
+
+// Resolve 解析实体句柄，只有实体为同一代且未销毁时才能成功
+func (h EntityHandle) Resolve(resolver EntityResolver) (Entity, bool) {
+	if h.IsNil() || resolver == nil {
+		return nil, false
+	}
+
+	entity, ok := resolver.GetEntity(h.Id)
+	if !ok {
+		return nil, false
+	}
+
+	if entity.GetHandle() != h || entity.GetState() > EntityState_Alive {
+		return nil, false
+	}
+
+	return entity, true
+}
+
+// String implements fmt.Stringer
+func (h EntityHandle) String() string {
+	return fmt.Sprintf(`{"id":%q, "generation":%d}`, h.Id, h.Generation)
+}
+
+// ComponentHandle 组件句柄，由实体句柄、组件Id与组件代数组成，组件每次初始化都会分配新的代数，用于安全的跨帧弱引用组件
+type ComponentHandle struct {
+	Entity     EntityHandle // 实体句柄
+	Id         uid.Id       // 组件Id
+	Generation int64        // 组件代数
+}
+
+// IsNil 是否为空
+func (h ComponentHandle) IsNil() bool {
+	return h.Entity.IsNil() || h.Generation == 0
+}
+
+// Resolve 解析组件句柄，只有实体与组件均为同一代且未销毁时才能成功
+func (h ComponentHandle) Resolve(resolver EntityResolver) (Component, bool) {
+	if h.IsNil() {
+		return nil, false
+	}
+
+	entity, ok := h.Entity.Resolve(resolver)
+	if !ok {
+		return nil, false
+	}
+
+	var found Component
+
+	entity.rangeAllComponents(func(comp Component) bool {
+		if comp.GetHandle() != h {
+			return true
+		}
+		found = comp
+		return false
+	})
+
+	if found == nil || found.GetState() > ComponentState_Alive {
+		return nil, false
+	}
+
+	return found, true
+}
+
+// String implements fmt.Stringer
+func (h ComponentHandle) String() string {
+	return fmt.Sprintf(`{"entity":%s, "id":%q, "generation":%d}`, h.Entity, h.Id, h.Generation)
+}
@@ -0,0 +1,160 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ec
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+// IProperty 组件属性接口
+type IProperty interface {
+	iProperty
+
+	// GetName 获取属性名称，即属性在组件结构体中的字段名
+	GetName() string
+	// GetComponent 获取属性所属组件
+	GetComponent() Component
+	// GetAny 获取属性值
+	GetAny() any
+}
+
+type iProperty interface {
+	bind(comp Component, name string)
+}
+
+// Property 组件属性，需要作为字段嵌入至组件结构体中，组件添加至实体时自动绑定。设置属性值时，会将组件记录至实体与运行时的脏数据集合中，并发送属性改变事件（EventComponentPropertyChanged），
+// 脏数据集合会在帧循环结束时（RunningStatus_FrameLoopEnd）清理，可用于UI同步与网络复制
+type Property[T any] struct {
+	value T
+	name  string
+	comp  Component
+}
+
+// Get 获取属性值
+func (p *Property[T]) Get() T {
+	return p.value
+}
+
+// Set 设置属性值，属性已绑定组件时，标记组件为脏组件并发送属性改变事件
+func (p *Property[T]) Set(v T) {
+	p.value = v
+	if p.comp != nil {
+		p.comp.markPropertyDirty(p.name)
+	}
+}
+
+// GetName 获取属性名称，即属性在组件结构体中的字段名
+func (p *Property[T]) GetName() string {
+	return p.name
+}
+
+// GetComponent 获取属性所属组件
+func (p *Property[T]) GetComponent() Component {
+	return p.comp
+}
+
+// GetAny 获取属性值
+func (p *Property[T]) GetAny() any {
+	return p.value
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Property[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler，不会标记组件为脏组件
+func (p *Property[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &p.value)
+}
+
+func (p *Property[T]) bind(comp Component, name string) {
+	p.comp = comp
+	p.name = name
+}
+
+type _PropertyField struct {
+	index []int
+	name  string
+}
+
+var (
+	propertyRT          = reflect.TypeFor[IProperty]()
+	propertyFieldsCache sync.Map
+)
+
+func lookupPropertyFields(rt reflect.Type) []_PropertyField {
+	if v, ok := propertyFieldsCache.Load(rt); ok {
+		return v.([]_PropertyField)
+	}
+
+	var fields []_PropertyField
+	collectPropertyFields(rt, nil, &fields)
+
+	v, _ := propertyFieldsCache.LoadOrStore(rt, fields)
+	return v.([]_PropertyField)
+}
+
+func collectPropertyFields(rt reflect.Type, index []int, fields *[]_PropertyField) {
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		fieldIndex := append(slices.Clone(index), i)
+
+		if reflect.PointerTo(field.Type).Implements(propertyRT) {
+			*fields = append(*fields, _PropertyField{
+				index: fieldIndex,
+				name:  field.Name,
+			})
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Type != componentBehaviorRT {
+			collectPropertyFields(field.Type, fieldIndex, fields)
+		}
+	}
+}
+
+func bindProperties(comp Component) []IProperty {
+	v := reflect.ValueOf(comp)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+
+	fields := lookupPropertyFields(v.Type())
+	if len(fields) <= 0 {
+		return nil
+	}
+
+	properties := make([]IProperty, 0, len(fields))
+
+	for _, field := range fields {
+		fv := v.FieldByIndex(field.index)
+		property := reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Interface().(IProperty)
+		property.bind(comp, field.name)
+		properties = append(properties, property)
+	}
+
+	return properties
+}
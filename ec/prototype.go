@@ -3,6 +3,7 @@ package ec
 import (
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/meta"
 	"git.golaxy.org/core/utils/option"
 	"reflect"
 )
@@ -11,26 +12,59 @@ import (
 type EntityPT interface {
 	// Prototype 实体原型名称
 	Prototype() string
+	// Base 基础实体原型名称
+	Base() string
 	// InstanceRT 实体实例反射类型
 	InstanceRT() reflect.Type
 	// Scope 可访问作用域
 	Scope() *Scope
 	// ComponentNameIndexing 是否开启组件名称索引
 	ComponentNameIndexing() *bool
+	// ComponentIdIndexing 是否开启组件Id索引
+	ComponentIdIndexing() *bool
+	// ComponentPTIndexing 是否开启组件原型索引
+	ComponentPTIndexing() *bool
 	// ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 	ComponentAwakeOnFirstTouch() *bool
 	// ComponentUniqueID 是否为实体组件分配唯一Id
 	ComponentUniqueID() *bool
 	// Extra 自定义原型属性
 	Extra() generic.SliceMap[string, any]
+	// Migration 实体在线升级迁移函数
+	Migration() EntityMigration
 	// CountComponents // 组件数量
 	CountComponents() int
 	// Component 获取组件
 	Component(idx int) BuiltinComponent
 	// Components 获取所有组件
 	Components() []BuiltinComponent
+	// CountChildren 子实体数量
+	CountChildren() int
+	// Child 获取子实体
+	Child(idx int) BuiltinChild
+	// Children 获取所有子实体
+	Children() []BuiltinChild
+	// PoolCapacity 实体对象池容量，为0时不开启对象池
+	PoolCapacity() int
+	// PoolStats 获取实体对象池统计信息
+	PoolStats() PoolStats
 	// Construct 创建实体
 	Construct(settings ...option.Setting[EntityOptions]) Entity
+	// Release 回收已销毁（Destroyed）的实体至对象池，未开启对象池或对象池已满时丢弃
+	Release(entity Entity)
+}
+
+// EntityMigration 实体在线升级迁移函数，实体原型重声明后升级存量实体时调用，参数依次为：实体、旧实体原型、将被删除的组件、将被添加的组件（尚未添加至实体）
+type EntityMigration = generic.Action4[Entity, EntityPT, []Component, []Component]
+
+// PoolStats 实体对象池统计信息
+type PoolStats struct {
+	Capacity int   // 容量
+	Idle     int   // 空闲数量
+	Hits     int64 // 复用次数
+	Misses   int64 // 新建次数
+	Releases int64 // 回收次数
+	Discards int64 // 丢弃次数
 }
 
 // BuiltinComponent 实体原型中的组件信息
@@ -42,6 +76,14 @@ type BuiltinComponent struct {
 	Extra     generic.SliceMap[string, any] // 自定义原型属性
 }
 
+// BuiltinChild 实体原型中的子实体信息
+type BuiltinChild struct {
+	Offset    int                             // 子实体位置
+	Prototype string                          // 子实体原型名称
+	Meta      meta.Meta                       // 子实体Meta信息，覆盖子实体原型中的Meta信息
+	Settings  []option.Setting[EntityOptions] // 子实体构建选项，覆盖子实体原型中的属性
+}
+
 // ComponentPT 组件原型接口
 type ComponentPT interface {
 	// Prototype 组件原型名称
@@ -65,6 +107,11 @@ func (_NoneEntityPT) Prototype() string {
 	return ""
 }
 
+// Base 基础实体原型名称
+func (_NoneEntityPT) Base() string {
+	return ""
+}
+
 // InstanceRT 实体实例反射类型
 func (_NoneEntityPT) InstanceRT() reflect.Type {
 	return nil
@@ -80,6 +127,16 @@ func (_NoneEntityPT) ComponentNameIndexing() *bool {
 	return nil
 }
 
+// ComponentIdIndexing 是否开启组件Id索引
+func (_NoneEntityPT) ComponentIdIndexing() *bool {
+	return nil
+}
+
+// ComponentPTIndexing 是否开启组件原型索引
+func (_NoneEntityPT) ComponentPTIndexing() *bool {
+	return nil
+}
+
 // ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 func (_NoneEntityPT) ComponentAwakeOnFirstTouch() *bool {
 	return nil
@@ -95,6 +152,11 @@ func (_NoneEntityPT) Extra() generic.SliceMap[string, any] {
 	return nil
 }
 
+// Migration 实体在线升级迁移函数
+func (_NoneEntityPT) Migration() EntityMigration {
+	return nil
+}
+
 // CountComponents // 组件数量
 func (_NoneEntityPT) CountComponents() int {
 	return 0
@@ -111,12 +173,41 @@ func (_NoneEntityPT) Components() []BuiltinComponent {
 	return nil
 }
 
+// CountChildren 子实体数量
+func (_NoneEntityPT) CountChildren() int {
+	return 0
+}
+
+// Child 获取子实体
+func (_NoneEntityPT) Child(idx int) BuiltinChild {
+	exception.Panicf("%w: %w: idx out of range", ErrEC, exception.ErrArgs)
+	panic("unreachable")
+}
+
+// Children 获取所有子实体
+func (_NoneEntityPT) Children() []BuiltinChild {
+	return nil
+}
+
+// PoolCapacity 实体对象池容量，为0时不开启对象池
+func (_NoneEntityPT) PoolCapacity() int {
+	return 0
+}
+
+// PoolStats 获取实体对象池统计信息
+func (_NoneEntityPT) PoolStats() PoolStats {
+	return PoolStats{}
+}
+
 // Construct 创建实体
 func (_NoneEntityPT) Construct(settings ...option.Setting[EntityOptions]) Entity {
 	exception.Panicf("%w: %w: none prototype", ErrEC, exception.ErrArgs)
 	panic("unreachable")
 }
 
+// Release 回收已销毁（Destroyed）的实体至对象池，未开启对象池或对象池已满时丢弃
+func (_NoneEntityPT) Release(entity Entity) {}
+
 type _NoneComponentPT struct{}
 
 // Prototype 组件原型名称
@@ -31,13 +31,22 @@ import (
 
 type _Entity struct {
 	prototype                  string
+	base                       string
 	instanceRT                 reflect.Type
 	scope                      *ec.Scope
 	componentNameIndexing      *bool
+	componentIdIndexing        *bool
+	componentPTIndexing        *bool
 	componentAwakeOnFirstTouch *bool
 	componentUniqueID          *bool
 	extra                      generic.SliceMap[string, any]
+	migration                  ec.EntityMigration
 	components                 []ec.BuiltinComponent
+	children                   []ec.BuiltinChild
+	injections                 [][]_ExtraInjection
+	pool                       *_EntityPool
+	declAtti                   EntityAttribute
+	declComps                  []any
 }
 
 // Prototype 实体原型名称
@@ -45,6 +54,11 @@ func (pt *_Entity) Prototype() string {
 	return pt.prototype
 }
 
+// Base 基础实体原型名称
+func (pt *_Entity) Base() string {
+	return pt.base
+}
+
 // InstanceRT 实体实例反射类型
 func (pt *_Entity) InstanceRT() reflect.Type {
 	return reflect.PointerTo(pt.instanceRT)
@@ -60,6 +74,16 @@ func (pt *_Entity) ComponentNameIndexing() *bool {
 	return pt.componentNameIndexing
 }
 
+// ComponentIdIndexing 是否开启组件Id索引
+func (pt *_Entity) ComponentIdIndexing() *bool {
+	return pt.componentIdIndexing
+}
+
+// ComponentPTIndexing 是否开启组件原型索引
+func (pt *_Entity) ComponentPTIndexing() *bool {
+	return pt.componentPTIndexing
+}
+
 // ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 func (pt *_Entity) ComponentAwakeOnFirstTouch() *bool {
 	return pt.componentAwakeOnFirstTouch
@@ -80,6 +104,11 @@ func (pt *_Entity) Extra() generic.SliceMap[string, any] {
 	return pt.extra
 }
 
+// Migration 实体在线升级迁移函数
+func (pt *_Entity) Migration() ec.EntityMigration {
+	return pt.migration
+}
+
 // Component 获取组件
 func (pt *_Entity) Component(idx int) ec.BuiltinComponent {
 	if idx < 0 || idx >= len(pt.components) {
@@ -93,18 +122,39 @@ func (pt *_Entity) Components() []ec.BuiltinComponent {
 	return slices.Clone(pt.components)
 }
 
+// CountChildren 子实体数量
+func (pt *_Entity) CountChildren() int {
+	return len(pt.children)
+}
+
+// Child 获取子实体
+func (pt *_Entity) Child(idx int) ec.BuiltinChild {
+	if idx < 0 || idx >= len(pt.children) {
+		exception.Panicf("%w: %w: idx out of range", ErrPt, exception.ErrArgs)
+	}
+	return pt.children[idx]
+}
+
+// Children 获取所有子实体
+func (pt *_Entity) Children() []ec.BuiltinChild {
+	return slices.Clone(pt.children)
+}
+
 // Construct 创建实体
 func (pt *_Entity) Construct(settings ...option.Setting[ec.EntityOptions]) ec.Entity {
 	options := option.Make(ec.With.Default())
-	if pt.instanceRT != nil {
-		options.InstanceFace = iface.MakeFaceT(reflect.New(pt.instanceRT).Interface().(ec.Entity))
-	}
 	if pt.scope != nil {
 		options.Scope = *pt.scope
 	}
 	if pt.componentNameIndexing != nil {
 		options.ComponentNameIndexing = *pt.componentNameIndexing
 	}
+	if pt.componentIdIndexing != nil {
+		options.ComponentIdIndexing = *pt.componentIdIndexing
+	}
+	if pt.componentPTIndexing != nil {
+		options.ComponentPTIndexing = *pt.componentPTIndexing
+	}
 	if pt.componentAwakeOnFirstTouch != nil {
 		options.ComponentAwakeOnFirstTouch = *pt.componentAwakeOnFirstTouch
 	}
@@ -113,10 +163,21 @@ func (pt *_Entity) Construct(settings ...option.Setting[ec.EntityOptions]) ec.En
 	}
 	options = option.Append(options, settings...)
 
-	return pt.assemble(ec.UnsafeNewEntity(options))
+	var pooled _PooledEntity
+
+	if options.InstanceFace.IsNil() {
+		if v, ok := pt.pool.get(); ok {
+			pooled = v
+			options.InstanceFace = pooled.instanceFace
+		} else if pt.instanceRT != nil {
+			options.InstanceFace = iface.MakeFaceT(reflect.New(pt.instanceRT).Interface().(ec.Entity))
+		}
+	}
+
+	return pt.assemble(ec.UnsafeNewEntity(options), pooled.components)
 }
 
-func (pt *_Entity) assemble(entity ec.Entity) ec.Entity {
+func (pt *_Entity) assemble(entity ec.Entity, pooledComps []ec.Component) ec.Entity {
 	if entity == nil {
 		exception.Panicf("%w: %w: entity is nil", ErrPt, exception.ErrArgs)
 	}
@@ -126,9 +187,16 @@ func (pt *_Entity) assemble(entity ec.Entity) ec.Entity {
 	for i := range pt.components {
 		builtin := &pt.components[i]
 
-		comp := builtin.PT.Construct()
+		var comp ec.Component
+		if i < len(pooledComps) && pooledComps[i] != nil {
+			comp = pooledComps[i]
+			ec.UnsafeComponent(comp).SetReflected(reflect.ValueOf(comp))
+		} else {
+			comp = builtin.PT.Construct()
+		}
 		ec.UnsafeComponent(comp).SetBuiltin(builtin)
 		ec.UnsafeComponent(comp).SetRemovable(builtin.Removable)
+		pt.injectExtra(i, comp)
 
 		if err := entity.AddComponent(builtin.Name, comp); err != nil {
 			exception.Panicf("%w: %w", ErrPt, err)
@@ -137,3 +205,28 @@ func (pt *_Entity) assemble(entity ec.Entity) ec.Entity {
 
 	return entity
 }
+
+func (pt *_Entity) constructComponent(idx int) ec.Component {
+	if idx < 0 || idx >= len(pt.components) {
+		exception.Panicf("%w: %w: idx out of range", ErrPt, exception.ErrArgs)
+	}
+
+	comp := pt.components[idx].PT.Construct()
+	pt.rebindComponent(idx, comp)
+	pt.injectExtra(idx, comp)
+
+	return comp
+}
+
+func (pt *_Entity) rebindComponent(idx int, comp ec.Component) {
+	if idx < 0 || idx >= len(pt.components) {
+		exception.Panicf("%w: %w: idx out of range", ErrPt, exception.ErrArgs)
+	}
+	if comp == nil {
+		exception.Panicf("%w: %w: comp is nil", ErrPt, exception.ErrArgs)
+	}
+
+	builtin := &pt.components[idx]
+	ec.UnsafeComponent(comp).SetBuiltin(builtin)
+	ec.UnsafeComponent(comp).SetRemovable(builtin.Removable)
+}
@@ -0,0 +1,285 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package pt
+
+import (
+	"encoding/json"
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/exception"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// _ExtraInjection 组件字段注入自定义属性
+type _ExtraInjection struct {
+	index []int         // 字段索引
+	value reflect.Value // 转换后的属性值
+}
+
+// resolveExtraInjections 解析组件中标记了`golaxy:"extra=<key>[,default=<value>]"`的字段，优先使用组件自定义属性，其次使用实体自定义属性，类型不匹配时panic
+func (pt *_Entity) resolveExtraInjections() {
+	pt.injections = make([][]_ExtraInjection, len(pt.components))
+
+	for i := range pt.components {
+		builtin := &pt.components[i]
+
+		compRT := builtin.PT.InstanceRT()
+		for compRT.Kind() == reflect.Pointer {
+			compRT = compRT.Elem()
+		}
+
+		if compRT.Kind() != reflect.Struct {
+			continue
+		}
+
+		for _, field := range reflect.VisibleFields(compRT) {
+			key, def, ok := parseExtraTag(field.Tag.Get("golaxy"))
+			if !ok {
+				continue
+			}
+
+			if !field.IsExported() {
+				exception.Panicf("%w: entity %q component %q field %q injected by extra %q must be exported", ErrPt, pt.prototype, builtin.Name, field.Name, key)
+			}
+
+			var raw any
+
+			if v, ok := builtin.Extra.Get(key); ok {
+				raw = v
+			} else if v, ok := pt.extra.Get(key); ok {
+				raw = v
+			} else if def != nil {
+				raw = *def
+			} else {
+				continue
+			}
+
+			value, err := convertExtra(raw, field.Type)
+			if err != nil {
+				exception.Panicf("%w: entity %q component %q field %q(%s) can't inject extra %q, %w", ErrPt, pt.prototype, builtin.Name, field.Name, field.Type, key, err)
+			}
+
+			pt.injections[i] = append(pt.injections[i], _ExtraInjection{
+				index: field.Index,
+				value: value,
+			})
+		}
+	}
+}
+
+// injectExtra 将自定义属性注入组件字段
+func (pt *_Entity) injectExtra(idx int, comp ec.Component) {
+	if idx >= len(pt.injections) || len(pt.injections[idx]) <= 0 {
+		return
+	}
+
+	compRV := reflect.ValueOf(comp)
+	for compRV.Kind() == reflect.Pointer {
+		compRV = compRV.Elem()
+	}
+
+	for _, injection := range pt.injections[idx] {
+		// 每次注入都深拷贝属性值，避免实例之间以及实例与原型自定义属性之间共享数据
+		compRV.FieldByIndex(injection.index).Set(copyExtra(injection.value, map[uintptr]reflect.Value{}))
+	}
+}
+
+// copyExtra 深拷贝属性值，包含结构体与数组中的引用类型
+func copyExtra(src reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return src
+		}
+		if dst, ok := visited[src.Pointer()]; ok {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		visited[src.Pointer()] = dst
+		dst.Elem().Set(copyExtra(src.Elem(), visited))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(copyExtra(src.Elem(), visited))
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := range src.Len() {
+			dst.Index(i).Set(copyExtra(src.Index(i), visited))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := range src.Len() {
+			dst.Index(i).Set(copyExtra(src.Index(i), visited))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		it := src.MapRange()
+		for it.Next() {
+			dst.SetMapIndex(copyExtra(it.Key(), visited), copyExtra(it.Value(), visited))
+		}
+		return dst
+
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := range src.NumField() {
+			if !src.Type().Field(i).IsExported() {
+				continue
+			}
+			dst.Field(i).Set(copyExtra(src.Field(i), visited))
+		}
+		return dst
+
+	default:
+		return src
+	}
+}
+
+func parseExtraTag(tag string) (key string, def *string, ok bool) {
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+
+		if v, found := strings.CutPrefix(opt, "extra="); found {
+			key, ok = v, v != ""
+		} else if v, found := strings.CutPrefix(opt, "default="); found {
+			def = &v
+		}
+	}
+	return
+}
+
+var (
+	durationRT = reflect.TypeFor[time.Duration]()
+	float64RT  = reflect.TypeFor[float64]()
+)
+
+func convertExtra(raw any, rt reflect.Type) (reflect.Value, error) {
+	if raw == nil {
+		return reflect.Zero(rt), nil
+	}
+
+	rv := reflect.ValueOf(raw)
+
+	if str, ok := raw.(string); ok {
+		switch {
+		case rt == durationRT:
+			d, err := time.ParseDuration(str)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(d), nil
+		case rt.Kind() == reflect.String:
+			return rv.Convert(rt), nil
+		}
+
+		// 字符串默认值按JSON解析
+		ptr := reflect.New(rt)
+		if err := json.Unmarshal([]byte(str), ptr.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("parse %q failed, %w", str, err)
+		}
+		return ptr.Elem(), nil
+	}
+
+	if rv.Type().AssignableTo(rt) {
+		return rv, nil
+	}
+
+	if isNumberKind(rv.Kind()) && isNumberKind(rt.Kind()) {
+		if !numberFits(rv, rt) {
+			return reflect.Value{}, fmt.Errorf("value %v overflows or loses precision", raw)
+		}
+		return rv.Convert(rt), nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	ptr := reflect.New(rt)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("type %T mismatch, %w", raw, err)
+	}
+
+	return ptr.Elem(), nil
+}
+
+// numberFits 数值转换为目标类型时是否不会溢出，浮点数转换为整数时需要为整数值，浮点数之间转换允许损失精度
+func numberFits(rv reflect.Value, rt reflect.Type) bool {
+	target := reflect.New(rt).Elem()
+
+	switch {
+	case rt.Kind() == reflect.Float32 || rt.Kind() == reflect.Float64:
+		return !target.OverflowFloat(rv.Convert(float64RT).Float())
+
+	case target.CanInt():
+		switch {
+		case rv.CanInt():
+			return !target.OverflowInt(rv.Int())
+		case rv.CanUint():
+			return rv.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(rv.Uint()))
+		default:
+			f := rv.Float()
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		}
+
+	case target.CanUint():
+		switch {
+		case rv.CanInt():
+			return rv.Int() >= 0 && !target.OverflowUint(uint64(rv.Int()))
+		case rv.CanUint():
+			return !target.OverflowUint(rv.Uint())
+		default:
+			f := rv.Float()
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+		}
+	}
+
+	return false
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -36,9 +36,9 @@ type EntityLib interface {
 
 	// GetComponentLib 获取组件原型库
 	GetComponentLib() ComponentLib
-	// Declare 声明实体原型
+	// Declare 声明实体原型，实体原型属性中设置了基础实体原型时，将继承基础实体原型的组件、子实体与属性
 	Declare(prototype any, comps ...any) ec.EntityPT
-	// Redeclare 重声明实体原型
+	// Redeclare 重声明实体原型，派生自此实体原型的所有实体原型将重新解析
 	Redeclare(prototype any, comps ...any) ec.EntityPT
 	// Undeclare 取消声明实体原型
 	Undeclare(prototype string)
@@ -52,6 +52,13 @@ type EntityLib interface {
 
 type iEntityLib interface {
 	setCallback(declareCB, redeclareCB, undeclareCB generic.Action1[ec.EntityPT])
+	redeclare(overrides map[string]_EntityDeclaration, prototype any, comps ...any) (ec.EntityPT, []ec.EntityPT)
+}
+
+// _EntityDeclaration 实体原型声明，重声明时用于替换派生实体原型的原有声明
+type _EntityDeclaration struct {
+	atti  EntityAttribute
+	comps []any
 }
 
 // NewEntityLib 创建实体原型库
@@ -71,6 +78,7 @@ type _EntityLib struct {
 	compLib                             ComponentLib
 	entityIndex                         map[string]*_Entity
 	entityList                          []*_Entity
+	staged                              map[string]*_Entity
 	declareCB, redeclareCB, undeclareCB generic.Action1[ec.EntityPT]
 }
 
@@ -86,15 +94,14 @@ func (lib *_EntityLib) GetComponentLib() ComponentLib {
 
 // Declare 声明实体原型
 func (lib *_EntityLib) Declare(prototype any, comps ...any) ec.EntityPT {
-	entityPT := lib.declare(false, prototype, comps...)
+	entityPT, _ := lib.declare(false, nil, prototype, comps...)
 	lib.declareCB.UnsafeCall(entityPT)
 	return entityPT
 }
 
-// Redeclare 重声明实体原型
+// Redeclare 重声明实体原型，派生自此实体原型的所有实体原型将重新解析
 func (lib *_EntityLib) Redeclare(prototype any, comps ...any) ec.EntityPT {
-	entityPT := lib.declare(true, prototype, comps...)
-	lib.redeclareCB.UnsafeCall(entityPT)
+	entityPT, _ := lib.redeclare(nil, prototype, comps...)
 	return entityPT
 }
 
@@ -155,7 +162,16 @@ func (lib *_EntityLib) setCallback(declareCB, redeclareCB, undeclareCB generic.A
 	lib.undeclareCB = undeclareCB
 }
 
-func (lib *_EntityLib) declare(re bool, prototype any, comps ...any) ec.EntityPT {
+func (lib *_EntityLib) redeclare(overrides map[string]_EntityDeclaration, prototype any, comps ...any) (ec.EntityPT, []ec.EntityPT) {
+	entityPT, dependents := lib.declare(true, overrides, prototype, comps...)
+	lib.redeclareCB.UnsafeCall(entityPT)
+	for _, dependent := range dependents {
+		lib.redeclareCB.UnsafeCall(dependent)
+	}
+	return entityPT, dependents
+}
+
+func (lib *_EntityLib) declare(re bool, overrides map[string]_EntityDeclaration, prototype any, comps ...any) (ec.EntityPT, []ec.EntityPT) {
 	if prototype == nil {
 		exception.Panicf("%w: %w: prototype is nil", ErrPt, exception.ErrArgs)
 	}
@@ -184,13 +200,138 @@ func (lib *_EntityLib) declare(re bool, prototype any, comps ...any) ec.EntityPT
 		exception.Panicf("%w: prototype can't empty", ErrPt)
 	}
 
+	if _, ok := lib.entityIndex[entityAtti.Prototype]; ok && !re {
+		exception.Panicf("%w: entity %q is already declared", ErrPt, entityAtti.Prototype)
+	}
+
+	entityPT := lib.resolve(entityAtti, slices.Clone(comps))
+
+	if !re {
+		lib.commit(entityPT, false)
+		return entityPT, nil
+	}
+
+	// 重新解析所有派生实体原型，先解析至暂存集合，全部解析成功后再一次性提交，避免解析失败时实体库处于半升级状态
+	lib.staged = map[string]*_Entity{entityPT.prototype: entityPT}
+	defer func() { lib.staged = nil }()
+
+	var resolvedList []*_Entity
+
+	for bases := []string{entityAtti.Prototype}; len(bases) > 0; bases = bases[1:] {
+		for _, dependent := range lib.entityList {
+			if dependent.base != bases[0] {
+				continue
+			}
+
+			decl := _EntityDeclaration{atti: dependent.declAtti, comps: dependent.declComps}
+			if override, ok := overrides[dependent.prototype]; ok {
+				decl = _EntityDeclaration{atti: override.atti, comps: slices.Clone(override.comps)}
+			}
+
+			resolved := lib.resolve(decl.atti, decl.comps)
+			lib.staged[resolved.prototype] = resolved
+
+			resolvedList = append(resolvedList, resolved)
+			bases = append(bases, resolved.prototype)
+		}
+	}
+
+	lib.commit(entityPT, false)
+
+	dependents := make([]ec.EntityPT, 0, len(resolvedList))
+	for _, resolved := range resolvedList {
+		lib.commit(resolved, true)
+		dependents = append(dependents, resolved)
+	}
+
+	return entityPT, dependents
+}
+
+func (lib *_EntityLib) commit(entityPT *_Entity, inPlace bool) {
+	lib.entityIndex[entityPT.prototype] = entityPT
+
+	idx := slices.IndexFunc(lib.entityList, func(pt *_Entity) bool {
+		return pt.prototype == entityPT.prototype
+	})
+
+	switch {
+	case idx >= 0 && inPlace:
+		lib.entityList[idx] = entityPT
+	case idx >= 0:
+		lib.entityList = append(slices.Delete(lib.entityList, idx, idx+1), entityPT)
+	default:
+		lib.entityList = append(lib.entityList, entityPT)
+	}
+}
+
+func (lib *_EntityLib) lookup(prototype string) (*_Entity, bool) {
+	if entityPT, ok := lib.staged[prototype]; ok {
+		return entityPT, true
+	}
+	entityPT, ok := lib.entityIndex[prototype]
+	return entityPT, ok
+}
+
+func (lib *_EntityLib) resolve(entityAtti EntityAttribute, comps []any) *_Entity {
 	entityPT := &_Entity{
-		prototype:                  entityAtti.Prototype,
-		scope:                      entityAtti.Scope,
-		componentNameIndexing:      entityAtti.ComponentNameIndexing,
-		componentAwakeOnFirstTouch: entityAtti.ComponentAwakeOnFirstTouch,
-		componentUniqueID:          entityAtti.ComponentUniqueID,
-		extra:                      entityAtti.Extra,
+		prototype: entityAtti.Prototype,
+		base:      entityAtti.Base,
+		declAtti:  entityAtti,
+		declComps: comps,
+	}
+
+	if entityAtti.Base != "" {
+		if lib.hasBase(entityAtti.Base, entityAtti.Prototype) {
+			exception.Panicf("%w: entity %q base %q forms a cycle", ErrPt, entityAtti.Prototype, entityAtti.Base)
+		}
+
+		basePT, ok := lib.lookup(entityAtti.Base)
+		if !ok {
+			exception.Panicf("%w: entity %q base %q was not declared", ErrPt, entityAtti.Prototype, entityAtti.Base)
+		}
+
+		entityPT.instanceRT = basePT.instanceRT
+		entityPT.scope = basePT.scope
+		entityPT.componentNameIndexing = basePT.componentNameIndexing
+		entityPT.componentIdIndexing = basePT.componentIdIndexing
+		entityPT.componentPTIndexing = basePT.componentPTIndexing
+		entityPT.componentAwakeOnFirstTouch = basePT.componentAwakeOnFirstTouch
+		entityPT.componentUniqueID = basePT.componentUniqueID
+		entityPT.extra = basePT.extra.Clone()
+		entityPT.migration = basePT.migration
+		entityPT.components = slices.Clone(basePT.components)
+		entityPT.children = slices.Clone(basePT.children)
+		entityPT.pool = &_EntityPool{capacity: basePT.pool.capacity}
+	} else {
+		entityPT.pool = &_EntityPool{}
+	}
+
+	if entityAtti.Scope != nil {
+		entityPT.scope = entityAtti.Scope
+	}
+	if entityAtti.ComponentNameIndexing != nil {
+		entityPT.componentNameIndexing = entityAtti.ComponentNameIndexing
+	}
+	if entityAtti.ComponentIdIndexing != nil {
+		entityPT.componentIdIndexing = entityAtti.ComponentIdIndexing
+	}
+	if entityAtti.ComponentPTIndexing != nil {
+		entityPT.componentPTIndexing = entityAtti.ComponentPTIndexing
+	}
+	if entityAtti.ComponentAwakeOnFirstTouch != nil {
+		entityPT.componentAwakeOnFirstTouch = entityAtti.ComponentAwakeOnFirstTouch
+	}
+	if entityAtti.ComponentUniqueID != nil {
+		entityPT.componentUniqueID = entityAtti.ComponentUniqueID
+	}
+	if entityAtti.PoolCapacity != 0 {
+		entityPT.pool.capacity = max(entityAtti.PoolCapacity, 0)
+	}
+	for _, kv := range entityAtti.Extra {
+		entityPT.extra.Add(kv.K, kv.V)
+	}
+	if entityAtti.Migration != nil {
+		entityPT.migration = entityAtti.Migration
 	}
 
 	if entityAtti.Instance != nil {
@@ -214,10 +355,27 @@ func (lib *_EntityLib) declare(re bool, prototype any, comps ...any) ec.EntityPT
 		entityPT.instanceRT = instanceRT
 	}
 
-	for i, comp := range comps {
-		builtin := ec.BuiltinComponent{
-			Offset: i,
+	for _, name := range entityAtti.RemovedComponents {
+		idx := slices.IndexFunc(entityPT.components, func(builtin ec.BuiltinComponent) bool {
+			return builtin.Name == name
+		})
+		if idx < 0 {
+			exception.Panicf("%w: entity %q removed component %q not found in base %q", ErrPt, entityAtti.Prototype, name, entityAtti.Base)
 		}
+		entityPT.components = slices.Delete(entityPT.components, idx, idx+1)
+	}
+
+	for _, comp := range comps {
+		switch v := comp.(type) {
+		case ChildAttribute:
+			entityPT.children = append(entityPT.children, lib.declareChild(entityAtti.Prototype, len(entityPT.children), v))
+			continue
+		case *ChildAttribute:
+			entityPT.children = append(entityPT.children, lib.declareChild(entityAtti.Prototype, len(entityPT.children), *v))
+			continue
+		}
+
+		var builtin ec.BuiltinComponent
 
 	retry:
 		switch v := comp.(type) {
@@ -233,12 +391,12 @@ func (lib *_EntityLib) declare(re bool, prototype any, comps ...any) ec.EntityPT
 		case string:
 			compPT, ok := lib.compLib.Get(v)
 			if !ok {
-				exception.Panicf("%w: entity %q builtin component %q was not declared", ErrPt, prototype, v)
+				exception.Panicf("%w: entity %q builtin component %q was not declared", ErrPt, entityAtti.Prototype, v)
 			}
 			builtin.PT = compPT
 		default:
 			if v == nil {
-				exception.Panicf("%w: entity %q builtin component is nil", ErrPt, prototype)
+				exception.Panicf("%w: entity %q builtin component is nil", ErrPt, entityAtti.Prototype)
 			}
 			builtin.PT = lib.compLib.Declare(v)
 		}
@@ -247,25 +405,83 @@ func (lib *_EntityLib) declare(re bool, prototype any, comps ...any) ec.EntityPT
 			builtin.Name = types.NameRT(builtin.PT.InstanceRT().Elem())
 		}
 
-		entityPT.components = append(entityPT.components, builtin)
-	}
-
-	if _, ok := lib.entityIndex[entityAtti.Prototype]; ok {
-		if re {
-			lib.entityList = slices.DeleteFunc(lib.entityList, func(pt *_Entity) bool {
-				return pt.prototype == prototype
-			})
+		// 与基础实体原型中同名的组件，在原位置替换
+		if idx := slices.IndexFunc(entityPT.components, func(exists ec.BuiltinComponent) bool {
+			return entityAtti.Base != "" && exists.Name == builtin.Name
+		}); idx >= 0 {
+			entityPT.components[idx] = builtin
 		} else {
-			exception.Panicf("%w: entity %q is already declared", ErrPt, prototype)
+			entityPT.components = append(entityPT.components, builtin)
 		}
 	}
 
-	lib.entityIndex[entityAtti.Prototype] = entityPT
-	lib.entityList = append(lib.entityList, entityPT)
+	for i := range entityPT.components {
+		entityPT.components[i].Offset = i
+	}
+
+	for i := range entityPT.children {
+		entityPT.children[i].Offset = i
+	}
+
+	entityPT.resolveExtraInjections()
 
 	return entityPT
 }
 
+func (lib *_EntityLib) hasBase(prototype, base string) bool {
+	for prototype != "" {
+		if prototype == base {
+			return true
+		}
+		entityPT, ok := lib.lookup(prototype)
+		if !ok {
+			return false
+		}
+		prototype = entityPT.base
+	}
+	return false
+}
+
+func (lib *_EntityLib) declareChild(prototype string, offset int, atti ChildAttribute) ec.BuiltinChild {
+	if atti.Prototype == "" {
+		exception.Panicf("%w: entity %q child prototype can't empty", ErrPt, prototype)
+	}
+
+	if _, ok := lib.lookup(atti.Prototype); !ok && atti.Prototype != prototype {
+		exception.Panicf("%w: entity %q child %q was not declared", ErrPt, prototype, atti.Prototype)
+	}
+
+	if lib.hasDescendant(atti.Prototype, prototype) {
+		exception.Panicf("%w: entity %q child %q forms a cycle", ErrPt, prototype, atti.Prototype)
+	}
+
+	return ec.BuiltinChild{
+		Offset:    offset,
+		Prototype: atti.Prototype,
+		Meta:      atti.Meta,
+		Settings:  slices.Clone(atti.Settings),
+	}
+}
+
+func (lib *_EntityLib) hasDescendant(prototype, descendant string) bool {
+	if prototype == descendant {
+		return true
+	}
+
+	entityPT, ok := lib.lookup(prototype)
+	if !ok {
+		return false
+	}
+
+	for i := range entityPT.children {
+		if lib.hasDescendant(entityPT.children[i].Prototype, descendant) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (lib *_EntityLib) undeclare(prototype string) (ec.EntityPT, bool) {
 	lib.Lock()
 	defer lib.Unlock()
@@ -23,17 +23,36 @@ import (
 	"git.golaxy.org/core/ec"
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/meta"
+	"git.golaxy.org/core/utils/option"
+	"slices"
 )
 
 // EntityAttribute 实体原型属性
 type EntityAttribute struct {
 	Prototype                  string                        // 实体原型名称（必填）
+	Base                       string                        // 基础实体原型名称，设置后继承基础实体原型，同名组件将在原位置替换
+	RemovedComponents          []string                      // 需要从基础实体原型中删除的组件名称
 	Instance                   any                           // 实体实例
 	Scope                      *ec.Scope                     // 可访问作用域
 	ComponentNameIndexing      *bool                         // 是否开启组件名称索引
+	ComponentIdIndexing        *bool                         // 是否开启组件Id索引
+	ComponentPTIndexing        *bool                         // 是否开启组件原型索引
 	ComponentAwakeOnFirstTouch *bool                         // 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 	ComponentUniqueID          *bool                         // 是否为实体组件分配唯一Id
+	PoolCapacity               int                           // 实体对象池容量，为0时不开启对象池
 	Extra                      generic.SliceMap[string, any] // 自定义属性
+	Migration                  ec.EntityMigration            // 实体在线升级迁移函数，实体原型重声明后升级存量实体时调用
+}
+
+func (atti EntityAttribute) SetBase(base string) EntityAttribute {
+	atti.Base = base
+	return atti
+}
+
+func (atti EntityAttribute) RemoveComponents(names ...string) EntityAttribute {
+	atti.RemovedComponents = append(slices.Clone(atti.RemovedComponents), names...)
+	return atti
 }
 
 func (atti EntityAttribute) SetInstance(instance any) EntityAttribute {
@@ -51,6 +70,16 @@ func (atti EntityAttribute) SetComponentNameIndexing(b bool) EntityAttribute {
 	return atti
 }
 
+func (atti EntityAttribute) SetComponentIdIndexing(b bool) EntityAttribute {
+	atti.ComponentIdIndexing = &b
+	return atti
+}
+
+func (atti EntityAttribute) SetComponentPTIndexing(b bool) EntityAttribute {
+	atti.ComponentPTIndexing = &b
+	return atti
+}
+
 func (atti EntityAttribute) SetComponentAwakeOnFirstTouch(b bool) EntityAttribute {
 	atti.ComponentAwakeOnFirstTouch = &b
 	return atti
@@ -61,6 +90,11 @@ func (atti EntityAttribute) SetComponentUniqueID(b bool) EntityAttribute {
 	return atti
 }
 
+func (atti EntityAttribute) SetPoolCapacity(n int) EntityAttribute {
+	atti.PoolCapacity = n
+	return atti
+}
+
 func (atti EntityAttribute) SetExtra(extra map[string]any) EntityAttribute {
 	atti.Extra = generic.MakeSliceMapFromGoMap(extra)
 	return atti
@@ -82,6 +116,11 @@ func (atti EntityAttribute) CombineExtra(extra map[string]any) EntityAttribute {
 	return atti
 }
 
+func (atti EntityAttribute) SetMigration(fun ec.EntityMigration) EntityAttribute {
+	atti.Migration = fun
+	return atti
+}
+
 // Entity 创建实体原型属性，用于注册实体原型时自定义相关属性
 func Entity(prototype string) EntityAttribute {
 	if prototype == "" {
@@ -131,6 +170,33 @@ func (atti ComponentAttribute) CombineExtra(extra map[string]any) ComponentAttri
 	return atti
 }
 
+// ChildAttribute 子实体原型属性
+type ChildAttribute struct {
+	Prototype string                             // 子实体原型名称（必填）
+	Meta      meta.Meta                          // 子实体Meta信息
+	Settings  []option.Setting[ec.EntityOptions] // 子实体构建选项
+}
+
+func (atti ChildAttribute) SetMeta(m meta.Meta) ChildAttribute {
+	atti.Meta = m
+	return atti
+}
+
+func (atti ChildAttribute) SetSettings(settings ...option.Setting[ec.EntityOptions]) ChildAttribute {
+	atti.Settings = append(slices.Clone(atti.Settings), settings...)
+	return atti
+}
+
+// Child 创建子实体原型属性，用于注册实体原型时声明子实体，子实体将随实体一同创建
+func Child(prototype string) ChildAttribute {
+	if prototype == "" {
+		exception.Panicf("%w: %w: prototype is empty", ErrPt, exception.ErrArgs)
+	}
+	return ChildAttribute{
+		Prototype: prototype,
+	}
+}
+
 // Component 创建组件原型属性，用于注册实体原型时自定义相关属性
 func Component(instance any) ComponentAttribute {
 	if instance == nil {
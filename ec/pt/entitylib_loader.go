@@ -0,0 +1,397 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package pt
+
+import (
+	"errors"
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/generic"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrDefinition = fmt.Errorf("%w: definition", ErrPt) // 原型定义错误
+)
+
+// EntityDefinition 实体原型定义，支持YAML与JSON格式
+type EntityDefinition struct {
+	Prototype                  string                `yaml:"prototype" json:"prototype"`                                                               // 实体原型名称（必填）
+	Base                       string                `yaml:"base,omitempty" json:"base,omitempty"`                                                     // 基础实体原型名称
+	Scope                      string                `yaml:"scope,omitempty" json:"scope,omitempty"`                                                   // 可访问作用域，local或global
+	ComponentNameIndexing      *bool                 `yaml:"component_name_indexing,omitempty" json:"component_name_indexing,omitempty"`               // 是否开启组件名称索引
+	ComponentIdIndexing        *bool                 `yaml:"component_id_indexing,omitempty" json:"component_id_indexing,omitempty"`                   // 是否开启组件Id索引
+	ComponentPTIndexing        *bool                 `yaml:"component_pt_indexing,omitempty" json:"component_pt_indexing,omitempty"`                   // 是否开启组件原型索引
+	ComponentAwakeOnFirstTouch *bool                 `yaml:"component_awake_on_first_touch,omitempty" json:"component_awake_on_first_touch,omitempty"` // 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
+	ComponentUniqueID          *bool                 `yaml:"component_unique_id,omitempty" json:"component_unique_id,omitempty"`                       // 是否为实体组件分配唯一Id
+	PoolCapacity               int                   `yaml:"pool_capacity,omitempty" json:"pool_capacity,omitempty"`                                   // 实体对象池容量
+	Extra                      map[string]any        `yaml:"extra,omitempty" json:"extra,omitempty"`                                                   // 自定义属性
+	RemovedComponents          []string              `yaml:"remove_components,omitempty" json:"remove_components,omitempty"`                           // 需要从基础实体原型中删除的组件名称
+	Components                 []ComponentDefinition `yaml:"components,omitempty" json:"components,omitempty"`                                         // 组件定义
+	Children                   []ChildDefinition     `yaml:"children,omitempty" json:"children,omitempty"`                                             // 子实体定义
+	File                       string                `yaml:"-" json:"-"`                                                                               // 定义所在文件
+	Line                       int                   `yaml:"-" json:"-"`                                                                               // 定义所在行号
+}
+
+// UnmarshalYAML 反序列化YAML，记录定义所在行号
+func (def *EntityDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type _EntityDefinition EntityDefinition
+	if err := node.Decode((*_EntityDefinition)(def)); err != nil {
+		return err
+	}
+	def.Line = node.Line
+	return nil
+}
+
+// ComponentDefinition 组件定义
+type ComponentDefinition struct {
+	Prototype string         `yaml:"prototype" json:"prototype"`                     // 组件原型名称（必填），需要已在组件原型库中声明
+	Name      string         `yaml:"name,omitempty" json:"name,omitempty"`           // 组件名称
+	Removable bool           `yaml:"removable,omitempty" json:"removable,omitempty"` // 是否可以删除
+	Extra     map[string]any `yaml:"extra,omitempty" json:"extra,omitempty"`         // 自定义属性
+	Line      int            `yaml:"-" json:"-"`                                     // 定义所在行号
+}
+
+// UnmarshalYAML 反序列化YAML，记录定义所在行号
+func (def *ComponentDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type _ComponentDefinition ComponentDefinition
+	if err := node.Decode((*_ComponentDefinition)(def)); err != nil {
+		return err
+	}
+	def.Line = node.Line
+	return nil
+}
+
+// ChildDefinition 子实体定义
+type ChildDefinition struct {
+	Prototype string         `yaml:"prototype" json:"prototype"`           // 子实体原型名称（必填）
+	Meta      map[string]any `yaml:"meta,omitempty" json:"meta,omitempty"` // 子实体Meta信息
+	Line      int            `yaml:"-" json:"-"`                           // 定义所在行号
+}
+
+// UnmarshalYAML 反序列化YAML，记录定义所在行号
+func (def *ChildDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type _ChildDefinition ChildDefinition
+	if err := node.Decode((*_ChildDefinition)(def)); err != nil {
+		return err
+	}
+	def.Line = node.Line
+	return nil
+}
+
+// ParseEntityDefinitions 解析实体原型定义，数据为实体原型定义列表，JSON作为YAML的子集同样支持
+func ParseEntityDefinitions(file string, data []byte) ([]EntityDefinition, error) {
+	var defs []EntityDefinition
+	if err := yaml.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrDefinition, file, err)
+	}
+	for i := range defs {
+		defs[i].File = file
+	}
+	return defs, nil
+}
+
+// LoadEntityFile 从文件加载实体原型定义，并声明至实体原型库
+func LoadEntityFile(lib EntityLib, file string) ([]ec.EntityPT, error) {
+	defs, err := readEntityFiles([]string{file})
+	if err != nil {
+		return nil, err
+	}
+	return DeclareEntityDefinitions(lib, false, defs)
+}
+
+// LoadEntityDir 从目录加载所有实体原型定义文件（.yaml、.yml、.json），并声明至实体原型库
+func LoadEntityDir(lib EntityLib, dir string) ([]ec.EntityPT, error) {
+	return loadEntityDir(lib, false, dir)
+}
+
+// ReloadEntityDir 从目录重新加载所有实体原型定义文件，已声明的实体原型将重声明（Redeclare），未声明的实体原型将声明
+func ReloadEntityDir(lib EntityLib, dir string) ([]ec.EntityPT, error) {
+	return loadEntityDir(lib, true, dir)
+}
+
+// DeclareEntityDefinitions 校验并声明实体原型定义，任意定义校验失败时，不会声明任何实体原型，re为true时，已声明的实体原型将重声明
+func DeclareEntityDefinitions(lib EntityLib, re bool, defs []EntityDefinition) ([]ec.EntityPT, error) {
+	if lib == nil {
+		return nil, fmt.Errorf("%w: lib is nil", ErrDefinition)
+	}
+
+	if err := validateEntityDefinitions(lib, re, defs); err != nil {
+		return nil, err
+	}
+
+	// 按依赖顺序声明，基础实体原型与子实体原型优先
+	pending := make([]*EntityDefinition, 0, len(defs))
+	for i := range defs {
+		pending = append(pending, &defs[i])
+	}
+
+	decls := make(map[string]_EntityDeclaration, len(defs))
+	for i := range defs {
+		decls[defs[i].Prototype] = makeEntityDeclaration(&defs[i])
+	}
+
+	// 重声明基础实体原型时，派生实体原型将使用本次定义重新解析，已重新解析的实体原型不再重复重声明
+	reresolved := map[string]ec.EntityPT{}
+
+	entityPTs := make([]ec.EntityPT, 0, len(defs))
+
+	for len(pending) > 0 {
+		progressed := false
+
+		for i := 0; i < len(pending); {
+			def := pending[i]
+
+			if slices.ContainsFunc(pending, func(other *EntityDefinition) bool {
+				return other != def && def.dependsOn(other.Prototype)
+			}) {
+				i++
+				continue
+			}
+
+			entityPT, ok := reresolved[def.Prototype]
+			if !ok {
+				var dependents []ec.EntityPT
+				var err error
+
+				entityPT, dependents, err = declareEntityDefinition(lib, re, def, decls)
+				if err != nil {
+					return entityPTs, err
+				}
+
+				for _, dependent := range dependents {
+					reresolved[dependent.Prototype()] = dependent
+				}
+			}
+
+			entityPTs = append(entityPTs, entityPT)
+			pending = slices.Delete(pending, i, i+1)
+			progressed = true
+		}
+
+		if !progressed {
+			var errs []error
+			for _, def := range pending {
+				errs = append(errs, def.errorf("entity %q has cyclic dependencies", def.Prototype))
+			}
+			return entityPTs, errors.Join(errs...)
+		}
+	}
+
+	return entityPTs, nil
+}
+
+func loadEntityDir(lib EntityLib, re bool, dir string) ([]ec.EntityPT, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDefinition, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	defs, err := readEntityFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeclareEntityDefinitions(lib, re, defs)
+}
+
+func readEntityFiles(files []string) ([]EntityDefinition, error) {
+	var defs []EntityDefinition
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDefinition, err)
+		}
+
+		fileDefs, err := ParseEntityDefinitions(file, data)
+		if err != nil {
+			return nil, err
+		}
+
+		defs = append(defs, fileDefs...)
+	}
+
+	return defs, nil
+}
+
+func validateEntityDefinitions(lib EntityLib, re bool, defs []EntityDefinition) error {
+	var errs []error
+
+	declared := func(prototype string) bool {
+		if slices.ContainsFunc(defs, func(def EntityDefinition) bool { return def.Prototype == prototype }) {
+			return true
+		}
+		_, ok := lib.Get(prototype)
+		return ok
+	}
+
+	for i := range defs {
+		def := &defs[i]
+
+		if def.Prototype == "" {
+			errs = append(errs, def.errorf("entity prototype can't empty"))
+			continue
+		}
+
+		if slices.ContainsFunc(defs[:i], func(other EntityDefinition) bool { return other.Prototype == def.Prototype }) {
+			errs = append(errs, def.errorf("entity %q is defined repeatedly", def.Prototype))
+		}
+
+		if _, ok := lib.Get(def.Prototype); ok && !re {
+			errs = append(errs, def.errorf("entity %q is already declared", def.Prototype))
+		}
+
+		if def.Base != "" && !declared(def.Base) {
+			errs = append(errs, def.errorf("entity %q base %q was not declared", def.Prototype, def.Base))
+		}
+
+		if _, err := parseScope(def.Scope); err != nil {
+			errs = append(errs, def.errorf("entity %q %s", def.Prototype, err))
+		}
+
+		for _, comp := range def.Components {
+			if comp.Prototype == "" {
+				errs = append(errs, def.errorfLine(comp.Line, "entity %q component prototype can't empty", def.Prototype))
+				continue
+			}
+			if _, ok := lib.GetComponentLib().Get(comp.Prototype); !ok {
+				errs = append(errs, def.errorfLine(comp.Line, "entity %q component %q was not declared", def.Prototype, comp.Prototype))
+			}
+		}
+
+		for _, child := range def.Children {
+			if child.Prototype == "" {
+				errs = append(errs, def.errorfLine(child.Line, "entity %q child prototype can't empty", def.Prototype))
+				continue
+			}
+			if !declared(child.Prototype) {
+				errs = append(errs, def.errorfLine(child.Line, "entity %q child %q was not declared", def.Prototype, child.Prototype))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func makeEntityDeclaration(def *EntityDefinition) _EntityDeclaration {
+	scope, _ := parseScope(def.Scope)
+
+	atti := EntityAttribute{
+		Prototype:                  def.Prototype,
+		Base:                       def.Base,
+		Scope:                      scope,
+		ComponentNameIndexing:      def.ComponentNameIndexing,
+		ComponentIdIndexing:        def.ComponentIdIndexing,
+		ComponentPTIndexing:        def.ComponentPTIndexing,
+		ComponentAwakeOnFirstTouch: def.ComponentAwakeOnFirstTouch,
+		ComponentUniqueID:          def.ComponentUniqueID,
+		PoolCapacity:               def.PoolCapacity,
+		RemovedComponents:          def.RemovedComponents,
+		Extra:                      generic.MakeSliceMapFromGoMap(def.Extra),
+	}
+
+	comps := make([]any, 0, len(def.Components)+len(def.Children))
+
+	for _, comp := range def.Components {
+		comps = append(comps, ComponentAttribute{
+			Instance:  comp.Prototype,
+			Name:      comp.Name,
+			Removable: comp.Removable,
+			Extra:     generic.MakeSliceMapFromGoMap(comp.Extra),
+		})
+	}
+
+	for _, child := range def.Children {
+		childAtti := Child(child.Prototype)
+		if child.Meta != nil {
+			childAtti = childAtti.SetMeta(generic.MakeSliceMapFromGoMap(child.Meta))
+		}
+		comps = append(comps, childAtti)
+	}
+
+	return _EntityDeclaration{atti: atti, comps: comps}
+}
+
+func declareEntityDefinition(lib EntityLib, re bool, def *EntityDefinition, decls map[string]_EntityDeclaration) (entityPT ec.EntityPT, dependents []ec.EntityPT, err error) {
+	decl := decls[def.Prototype]
+
+	panicErr := generic.CastAction0(func() {
+		if _, ok := lib.Get(def.Prototype); ok && re {
+			entityPT, dependents = lib.redeclare(decls, decl.atti, decl.comps...)
+		} else {
+			entityPT = lib.Declare(decl.atti, decl.comps...)
+		}
+	}).SafeCall()
+	if panicErr != nil {
+		return nil, nil, def.errorf("%s", panicErr)
+	}
+
+	return entityPT, dependents, nil
+}
+
+func parseScope(s string) (*ec.Scope, error) {
+	var scope ec.Scope
+
+	switch strings.ToLower(s) {
+	case "":
+		return nil, nil
+	case "local":
+		scope = ec.Scope_Local
+	case "global":
+		scope = ec.Scope_Global
+	default:
+		return nil, fmt.Errorf("invalid scope %q", s)
+	}
+
+	return &scope, nil
+}
+
+func (def *EntityDefinition) dependsOn(prototype string) bool {
+	if def.Base == prototype {
+		return true
+	}
+	return slices.ContainsFunc(def.Children, func(child ChildDefinition) bool {
+		return child.Prototype == prototype
+	})
+}
+
+func (def *EntityDefinition) errorf(format string, args ...any) error {
+	return def.errorfLine(def.Line, format, args...)
+}
+
+func (def *EntityDefinition) errorfLine(line int, format string, args ...any) error {
+	return fmt.Errorf("%w: %s:%d: %s", ErrDefinition, def.File, line, fmt.Sprintf(format, args...))
+}
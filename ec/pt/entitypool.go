@@ -0,0 +1,164 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package pt
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/iface"
+	"reflect"
+	"sync"
+)
+
+// EntityReset 实体回收至对象池时，实现此接口的实体将调用Reset()自行重置状态，未实现时实体将被零值化
+type EntityReset interface {
+	Reset()
+}
+
+// ComponentReset 实体回收至对象池时，实现此接口的组件将调用Reset()自行重置状态，未实现时组件将被零值化
+type ComponentReset interface {
+	Reset()
+}
+
+type _PooledEntity struct {
+	instanceFace iface.Face[ec.Entity]
+	components   []ec.Component
+}
+
+type _EntityPool struct {
+	sync.Mutex
+	capacity int
+	idle     []_PooledEntity
+	stats    ec.PoolStats
+}
+
+func (pool *_EntityPool) get() (_PooledEntity, bool) {
+	if pool.capacity <= 0 {
+		return _PooledEntity{}, false
+	}
+
+	pool.Lock()
+	defer pool.Unlock()
+
+	if len(pool.idle) <= 0 {
+		pool.stats.Misses++
+		return _PooledEntity{}, false
+	}
+
+	pooled := pool.idle[len(pool.idle)-1]
+	pool.idle[len(pool.idle)-1] = _PooledEntity{}
+	pool.idle = pool.idle[:len(pool.idle)-1]
+	pool.stats.Hits++
+
+	return pooled, true
+}
+
+func (pool *_EntityPool) put(pooled _PooledEntity, reset func()) bool {
+	pool.Lock()
+	defer pool.Unlock()
+
+	if len(pool.idle) >= pool.capacity {
+		pool.stats.Discards++
+		return false
+	}
+
+	// 确认可以放入对象池后再重置，对象池已满时丢弃的实体保持销毁（Destroyed）状态
+	reset()
+
+	pool.idle = append(pool.idle, pooled)
+	pool.stats.Releases++
+
+	return true
+}
+
+func (pool *_EntityPool) getStats() ec.PoolStats {
+	pool.Lock()
+	defer pool.Unlock()
+
+	stats := pool.stats
+	stats.Capacity = pool.capacity
+	stats.Idle = len(pool.idle)
+
+	return stats
+}
+
+// PoolCapacity 实体对象池容量，为0时不开启对象池
+func (pt *_Entity) PoolCapacity() int {
+	return pt.pool.capacity
+}
+
+// PoolStats 获取实体对象池统计信息
+func (pt *_Entity) PoolStats() ec.PoolStats {
+	return pt.pool.getStats()
+}
+
+// Release 回收已销毁（Destroyed）的实体至对象池，未开启对象池或对象池已满时丢弃
+func (pt *_Entity) Release(entity ec.Entity) {
+	if pt.pool.capacity <= 0 || entity == nil {
+		return
+	}
+
+	if entity.GetPT() != ec.EntityPT(pt) || entity.GetState() != ec.EntityState_Destroyed {
+		return
+	}
+
+	pooled := _PooledEntity{
+		instanceFace: ec.UnsafeEntity(entity).GetOptions().InstanceFace,
+		components:   make([]ec.Component, len(pt.components)),
+	}
+
+	ec.UnsafeEntity(entity).RangeAllComponents(func(comp ec.Component) bool {
+		if comp.GetState() != ec.ComponentState_Destroyed {
+			return true
+		}
+
+		builtin := comp.GetBuiltin()
+		if builtin.Offset < 0 || builtin.Offset >= len(pt.components) || pooled.components[builtin.Offset] != nil {
+			return true
+		}
+
+		if builtin.PT != pt.components[builtin.Offset].PT || builtin.Name != pt.components[builtin.Offset].Name {
+			return true
+		}
+
+		pooled.components[builtin.Offset] = comp
+		return true
+	})
+
+	pt.pool.put(pooled, func() {
+		for _, comp := range pooled.components {
+			if comp == nil {
+				continue
+			}
+			if cb, ok := comp.(ComponentReset); ok {
+				cb.Reset()
+				ec.UnsafeComponent(comp).Reset()
+			} else {
+				reflect.ValueOf(comp).Elem().SetZero()
+			}
+		}
+
+		if cb, ok := entity.(EntityReset); ok {
+			cb.Reset()
+			ec.UnsafeEntity(entity).Reset()
+		} else {
+			reflect.ValueOf(entity).Elem().SetZero()
+		}
+	})
+}
@@ -0,0 +1,54 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package pt
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/exception"
+)
+
+// Deprecated: UnsafeEntityPT 访问实体原型的内部方法
+func UnsafeEntityPT(entityPT ec.EntityPT) _UnsafeEntityPT {
+	return _UnsafeEntityPT{
+		EntityPT: entityPT,
+	}
+}
+
+type _UnsafeEntityPT struct {
+	ec.EntityPT
+}
+
+// ConstructComponent 创建实体原型中的组件，并注入组件内置信息与自定义原型属性
+func (u _UnsafeEntityPT) ConstructComponent(idx int) ec.Component {
+	entityPT, ok := u.EntityPT.(*_Entity)
+	if !ok {
+		exception.Panicf("%w: %w: unsupported entity prototype type %T", ErrPt, exception.ErrArgs, u.EntityPT)
+	}
+	return entityPT.constructComponent(idx)
+}
+
+// RebindComponent 将组件的内置信息重新绑定至实体原型中的组件
+func (u _UnsafeEntityPT) RebindComponent(idx int, comp ec.Component) {
+	entityPT, ok := u.EntityPT.(*_Entity)
+	if !ok {
+		exception.Panicf("%w: %w: unsupported entity prototype type %T", ErrPt, exception.ErrArgs, u.EntityPT)
+	}
+	entityPT.rebindComponent(idx, comp)
+}
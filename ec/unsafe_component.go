@@ -72,6 +72,11 @@ func (u _UnsafeComponent) SetRemovable(b bool) {
 	u.setRemovable(b)
 }
 
+// Reset 重置组件行为的内部状态
+func (u _UnsafeComponent) Reset() {
+	u.reset()
+}
+
 // GetCallingStateBits 获取调用状态标志位
 func (u _UnsafeComponent) GetCallingStateBits() *types.Bits16 {
 	return u.getCallingStateBits()
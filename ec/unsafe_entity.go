@@ -21,6 +21,7 @@ package ec
 
 import (
 	"context"
+	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/types"
 	"git.golaxy.org/core/utils/uid"
@@ -68,6 +69,11 @@ func (u _UnsafeEntity) SetContext(ctx iface.Cache) {
 	u.setContext(ctx)
 }
 
+// SetComponentInjector 设置组件注入器，组件添加或替换至实体前调用，返回错误时不会添加或替换组件
+func (u _UnsafeEntity) SetComponentInjector(injector generic.Func1[Component, error]) {
+	u.setComponentInjector(injector)
+}
+
 // GetVersion 获取组件列表变化版本号
 func (u _UnsafeEntity) GetVersion() int64 {
 	return u.getVersion()
@@ -78,6 +84,11 @@ func (u _UnsafeEntity) SetState(state EntityState) {
 	u.setState(state)
 }
 
+// ClearDirty 清理实体与组件的脏数据
+func (u _UnsafeEntity) ClearDirty() {
+	u.clearDirty()
+}
+
 // SetReflected 设置反射值
 func (u _UnsafeEntity) SetReflected(v reflect.Value) {
 	u.setReflected(v)
@@ -88,6 +99,31 @@ func (u _UnsafeEntity) GetProcessedStateBits() *types.Bits16 {
 	return u.getProcessedStateBits()
 }
 
+// SetLifecycleError 设置实体生命周期错误
+func (u _UnsafeEntity) SetLifecycleError(err error) {
+	u.setLifecycleError(err)
+}
+
+// GetLifecycleError 获取实体生命周期错误
+func (u _UnsafeEntity) GetLifecycleError() error {
+	return u.getLifecycleError()
+}
+
+// UpdateActiveInHierarchy 更新实体在实体树中的激活状态
+func (u _UnsafeEntity) UpdateActiveInHierarchy() {
+	u.updateActiveInHierarchy()
+}
+
+// RangeAllComponents 遍历所有组件，包含已销毁的组件
+func (u _UnsafeEntity) RangeAllComponents(fun generic.Func1[Component, bool]) {
+	u.rangeAllComponents(fun)
+}
+
+// Reset 重置实体行为的内部状态
+func (u _UnsafeEntity) Reset() {
+	u.reset()
+}
+
 // RemoveComponentByRef 使用组件引用删除组件
 func (u _UnsafeEntity) RemoveComponentByRef(comp Component) {
 	u.removeComponentByRef(comp)
@@ -0,0 +1,55 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/ec/pt"
+	"git.golaxy.org/core/service"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/types"
+)
+
+// AddComponentByPT 使用组件原型名称向运行时中的实体添加组件，组件原型从服务的组件原型库中查询，组件名称为空时使用组件实例类型名称
+func AddComponentByPT(entity ec.Entity, name, prototype string) error {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrCore, ErrArgs)
+	}
+
+	if entity.GetState() < ec.EntityState_Enter || entity.GetState() > ec.EntityState_Alive {
+		return fmt.Errorf("%w: invalid entity %q state %q", ErrCore, entity.GetId(), entity.GetState())
+	}
+
+	return addComponentByPT(service.Current(entity).GetEntityLib().GetComponentLib(), entity, name, prototype)
+}
+
+func addComponentByPT(compLib pt.ComponentLib, entity ec.Entity, name, prototype string) error {
+	compPT, ok := compLib.Get(prototype)
+	if !ok {
+		return fmt.Errorf("%w: component prototype %q not declared", ErrCore, prototype)
+	}
+
+	if name == "" {
+		name = types.NameRT(compPT.InstanceRT().Elem())
+	}
+
+	return entity.AddComponent(name, compPT.Construct())
+}
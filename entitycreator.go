@@ -20,6 +20,7 @@
 package core
 
 import (
+	"fmt"
 	"git.golaxy.org/core/ec"
 	"git.golaxy.org/core/ec/pt"
 	"git.golaxy.org/core/internal/ictx"
@@ -30,6 +31,8 @@ import (
 	"git.golaxy.org/core/utils/meta"
 	"git.golaxy.org/core/utils/option"
 	"git.golaxy.org/core/utils/uid"
+	"github.com/elliotchance/pie/v2"
+	"slices"
 )
 
 // CreateEntity 创建实体
@@ -49,6 +52,7 @@ type EntityCreator struct {
 	prototype string
 	parentId  uid.Id
 	settings  []option.Setting[ec.EntityOptions]
+	comps     []_ComponentKey
 }
 
 // InstanceFace 设置实例，用于扩展实体能力
@@ -81,6 +85,18 @@ func (c EntityCreator) ComponentNameIndexing(b bool) EntityCreator {
 	return c
 }
 
+// ComponentIdIndexing 是否开启组件Id索引
+func (c EntityCreator) ComponentIdIndexing(b bool) EntityCreator {
+	c.settings = append(c.settings, ec.With.ComponentIdIndexing(b))
+	return c
+}
+
+// ComponentPTIndexing 是否开启组件原型索引
+func (c EntityCreator) ComponentPTIndexing(b bool) EntityCreator {
+	c.settings = append(c.settings, ec.With.ComponentPTIndexing(b))
+	return c
+}
+
 // ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 func (c EntityCreator) ComponentAwakeOnFirstTouch(b bool) EntityCreator {
 	c.settings = append(c.settings, ec.With.ComponentAwakeOnFirstTouch(b))
@@ -105,23 +121,80 @@ func (c EntityCreator) ParentId(id uid.Id) EntityCreator {
 	return c
 }
 
-// Spawn 创建实体
+// AddComponent 使用组件原型名称添加原型之外的组件，组件原型从服务的组件原型库中查询，组件名称为空时使用组件实例类型名称
+func (c EntityCreator) AddComponent(prototype string, name ...string) EntityCreator {
+	c.comps = append(slices.Clone(c.comps), _ComponentKey{name: pie.First(name), prototype: prototype})
+	return c
+}
+
+// Spawn 创建实体，实体原型中声明的子实体将一同创建，任意子实体创建失败或生命周期回调（AwakeE、StartE）返回错误时，已创建的实体将被销毁并返回错误
 func (c EntityCreator) Spawn() (ec.Entity, error) {
 	if c.rtCtx == nil {
 		exception.Panicf("%w: rtCtx is nil", ErrCore)
 	}
 
-	entity := pt.For(service.Current(c.rtCtx), c.prototype).Construct(c.settings...)
+	return spawnEntity(c.rtCtx, pt.For(service.Current(c.rtCtx), c.prototype), c.settings, c.comps, c.parentId)
+}
+
+func spawnEntity(rtCtx runtime.Context, entityPT ec.EntityPT, settings []option.Setting[ec.EntityOptions], comps []_ComponentKey, parentId uid.Id) (ec.Entity, error) {
+	entity, err := constructEntity(rtCtx, entityPT, settings, comps)
+	if err != nil {
+		return nil, err
+	}
 
-	if c.parentId.IsNil() {
-		if err := c.rtCtx.GetEntityManager().AddEntity(entity); err != nil {
+	if parentId.IsNil() {
+		if err := rtCtx.GetEntityManager().AddEntity(entity); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := c.rtCtx.GetEntityTree().AddNode(entity, c.parentId); err != nil {
+		if err := rtCtx.GetEntityTree().AddNode(entity, parentId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := spawnChildren(rtCtx, entityPT, entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func constructEntity(rtCtx runtime.Context, entityPT ec.EntityPT, settings []option.Setting[ec.EntityOptions], comps []_ComponentKey) (ec.Entity, error) {
+	entity := entityPT.Construct(settings...)
+
+	for _, comp := range comps {
+		if err := addComponentByPT(service.Current(rtCtx).GetEntityLib().GetComponentLib(), entity, comp.name, comp.prototype); err != nil {
 			return nil, err
 		}
 	}
 
 	return entity, nil
 }
+
+func spawnChildren(rtCtx runtime.Context, entityPT ec.EntityPT, entity ec.Entity) error {
+	if entityPT.CountChildren() <= 0 {
+		return nil
+	}
+
+	entityLib := service.Current(rtCtx).GetEntityLib()
+
+	for _, child := range entityPT.Children() {
+		childPT, ok := entityLib.Get(child.Prototype)
+		if !ok {
+			rtCtx.GetEntityManager().RemoveEntity(entity.GetId())
+			return fmt.Errorf("%w: entity %q child prototype %q not declared", ErrCore, entityPT.Prototype(), child.Prototype)
+		}
+
+		childSettings := child.Settings
+		if child.Meta != nil {
+			childSettings = append(slices.Clone(childSettings), ec.With.Meta(child.Meta.Clone()))
+		}
+
+		if _, err := spawnEntity(rtCtx, childPT, childSettings, nil, entity.GetId()); err != nil {
+			rtCtx.GetEntityManager().RemoveEntity(entity.GetId())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"context"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/runtime"
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+)
+
+// SpawnEntityAsync 在目标运行时中异步创建实体，支持在任意线程中调用，configure 将在目标运行时的线程中调用，用于设置实体构建器，创建失败时返回错误
+func SpawnEntityAsync(provider ictx.ConcurrentContextProvider, prototype string, configure ...generic.Func1[EntityCreator, EntityCreator]) async.AsyncRetT[ec.ConcurrentEntity] {
+	if provider == nil {
+		exception.Panicf("%w: %w: provider is nil", ErrCore, ErrArgs)
+	}
+
+	ctx := runtime.UnsafeConcurrentContext(runtime.Concurrent(provider)).GetContext()
+
+	callRet := ctx.CallAsync(func(...any) async.Ret {
+		creator := CreateEntity(ctx, prototype)
+		for _, fun := range configure {
+			creator = fun.UnsafeCall(creator)
+		}
+
+		entity, err := creator.Spawn()
+		if err != nil {
+			return async.MakeRet(nil, err)
+		}
+
+		return async.MakeRet(entity, nil)
+	})
+
+	asyncRet := async.MakeAsyncRetT[ec.ConcurrentEntity]()
+
+	go func() {
+		asyncRet <- async.CastRetT[ec.ConcurrentEntity](callRet.Wait(context.Background()))
+		close(asyncRet)
+	}()
+
+	return asyncRet
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/ec/pt"
+	"git.golaxy.org/core/runtime"
+	"git.golaxy.org/core/service"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/option"
+)
+
+// SpawnMany 使用相同的构建参数批量创建n个实体，返回与实体一一对应的错误，创建失败时对应位置的实体为nil，不能与 InstanceFace、PersistId 等只适用于单个实体的参数同时使用
+func (c EntityCreator) SpawnMany(n int) ([]ec.Entity, []error) {
+	if c.rtCtx == nil {
+		exception.Panicf("%w: rtCtx is nil", ErrCore)
+	}
+
+	if n < 0 {
+		exception.Panicf("%w: %w: n less than 0 is invalid", ErrCore, ErrArgs)
+	}
+
+	options := option.Append(ec.EntityOptions{}, c.settings...)
+	if !options.InstanceFace.IsNil() {
+		exception.Panicf("%w: %w: InstanceFace can't be used with SpawnMany", ErrCore, ErrArgs)
+	}
+	if !options.PersistId.IsNil() {
+		exception.Panicf("%w: %w: PersistId can't be used with SpawnMany", ErrCore, ErrArgs)
+	}
+
+	specs := make([]EntityCreator, n)
+	for i := range specs {
+		specs[i] = c
+	}
+
+	return spawnBatch(c.rtCtx, specs)
+}
+
+// SpawnBatch 使用不同的实体构建器批量创建实体，所有实体构建器需要属于同一个运行时，返回与实体构建器一一对应的错误，创建失败时对应位置的实体为nil
+func SpawnBatch(specs []EntityCreator) ([]ec.Entity, []error) {
+	if len(specs) <= 0 {
+		return nil, nil
+	}
+
+	rtCtx := specs[0].rtCtx
+	if rtCtx == nil {
+		exception.Panicf("%w: rtCtx is nil", ErrCore)
+	}
+
+	for i := range specs {
+		if specs[i].rtCtx != rtCtx {
+			exception.Panicf("%w: %w: specs[%d] belongs to another runtime", ErrCore, ErrArgs, i)
+		}
+	}
+
+	return spawnBatch(rtCtx, specs)
+}
+
+func spawnBatch(rtCtx runtime.Context, specs []EntityCreator) ([]ec.Entity, []error) {
+	entities := make([]ec.Entity, len(specs))
+	errs := make([]error, len(specs))
+
+	svcCtx := service.Current(rtCtx)
+
+	roots := make([]ec.Entity, 0, len(specs))
+	rootsAt := make([]int, 0, len(specs))
+
+	for i := range specs {
+		entity, err := constructEntity(rtCtx, pt.For(svcCtx, specs[i].prototype), specs[i].settings, specs[i].comps)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		entities[i] = entity
+
+		if specs[i].parentId.IsNil() {
+			roots = append(roots, entity)
+			rootsAt = append(rootsAt, i)
+		}
+	}
+
+	// 无父实体的实体批量添加，有父实体的实体在其后逐个添加，以支持父实体在同一批次中创建
+	for i, err := range rtCtx.GetEntityManager().AddEntities(roots) {
+		if err != nil {
+			errs[rootsAt[i]] = err
+			entities[rootsAt[i]] = nil
+		}
+	}
+
+	for i := range specs {
+		if entities[i] == nil || specs[i].parentId.IsNil() {
+			continue
+		}
+
+		if err := rtCtx.GetEntityTree().AddNode(entities[i], specs[i].parentId); err != nil {
+			errs[i] = err
+			entities[i] = nil
+		}
+	}
+
+	for i, entity := range entities {
+		if entity == nil {
+			continue
+		}
+
+		if err := spawnChildren(rtCtx, entity.GetPT(), entity); err != nil {
+			errs[i] = err
+			entities[i] = nil
+		}
+	}
+
+	return entities, errs
+}
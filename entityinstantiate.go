@@ -0,0 +1,98 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/runtime"
+	"git.golaxy.org/core/service"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/uid"
+	"reflect"
+)
+
+// Instantiate 克隆实体与其子孙实体，克隆的实体使用新的实体Id，并加入源实体所在的父实体下，组件状态克隆参考ec.CloneComponentState()，任意实体克隆失败时，已克隆的实体将被销毁
+func Instantiate(entity ec.Entity) (ec.Entity, error) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrCore, ErrArgs)
+	}
+
+	if entity.GetState() < ec.EntityState_Awake || entity.GetState() > ec.EntityState_Alive {
+		return nil, fmt.Errorf("%w: invalid entity %q state %q", ErrCore, entity.GetId(), entity.GetState())
+	}
+
+	rtCtx := runtime.Current(entity)
+
+	var parentId uid.Id
+	if parent, ok := rtCtx.GetEntityTree().GetParent(entity.GetId()); ok {
+		parentId = parent.GetId()
+	}
+
+	return instantiateEntity(rtCtx, entity, parentId)
+}
+
+func instantiateEntity(rtCtx runtime.Context, src ec.Entity, parentId uid.Id) (ec.Entity, error) {
+	entityLib := service.Current(rtCtx).GetEntityLib()
+
+	entityPT, ok := entityLib.Get(src.GetPT().Prototype())
+	if !ok {
+		return nil, fmt.Errorf("%w: entity prototype %q not declared", ErrCore, src.GetPT().Prototype())
+	}
+
+	entity := entityPT.Construct(ec.With.Meta(src.GetMeta().Clone()))
+
+	var compKeys []_ComponentKey
+	src.RangeComponents(func(comp ec.Component) bool {
+		compKeys = append(compKeys, _ComponentKey{
+			name:       comp.GetName(),
+			prototype:  comp.GetBuiltin().PT.Prototype(),
+			instanceRT: reflect.TypeOf(comp).Elem(),
+		})
+		return true
+	})
+
+	if err := complementComponents(entityLib, entity, compKeys); err != nil {
+		return nil, err
+	}
+
+	if err := ec.CloneEntityState(entity, src); err != nil {
+		return nil, err
+	}
+
+	if parentId.IsNil() {
+		if err := rtCtx.GetEntityManager().AddEntity(entity); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := rtCtx.GetEntityTree().AddNode(entity, parentId); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, child := range rtCtx.GetEntityTree().GetChildren(src.GetId()) {
+		if _, err := instantiateEntity(rtCtx, child, entity.GetId()); err != nil {
+			rtCtx.GetEntityManager().RemoveEntity(entity.GetId())
+			return nil, err
+		}
+	}
+
+	return entity, nil
+}
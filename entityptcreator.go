@@ -46,6 +46,12 @@ type EntityPTCreator struct {
 	comps  []any
 }
 
+// Base 设置基础实体原型，继承基础实体原型的组件、子实体与属性
+func (c EntityPTCreator) Base(base string) EntityPTCreator {
+	c.atti.Base = base
+	return c
+}
+
 // Instance 设置实例，用于扩展实体能力
 func (c EntityPTCreator) Instance(instance any) EntityPTCreator {
 	c.atti.Instance = instance
@@ -64,6 +70,18 @@ func (c EntityPTCreator) ComponentNameIndexing(b bool) EntityPTCreator {
 	return c
 }
 
+// ComponentIdIndexing 是否开启组件Id索引
+func (c EntityPTCreator) ComponentIdIndexing(b bool) EntityPTCreator {
+	c.atti.ComponentIdIndexing = &b
+	return c
+}
+
+// ComponentPTIndexing 是否开启组件原型索引
+func (c EntityPTCreator) ComponentPTIndexing(b bool) EntityPTCreator {
+	c.atti.ComponentPTIndexing = &b
+	return c
+}
+
 // ComponentAwakeOnFirstTouch 当实体组件首次被访问时，生命周期是否进入唤醒（Awake）
 func (c EntityPTCreator) ComponentAwakeOnFirstTouch(b bool) EntityPTCreator {
 	c.atti.ComponentAwakeOnFirstTouch = &b
@@ -76,6 +94,12 @@ func (c EntityPTCreator) ComponentUniqueID(b bool) EntityPTCreator {
 	return c
 }
 
+// PoolCapacity 实体对象池容量，为0时不开启对象池，开启后实体销毁时将回收至对象池，创建实体时优先复用
+func (c EntityPTCreator) PoolCapacity(n int) EntityPTCreator {
+	c.atti.PoolCapacity = n
+	return c
+}
+
 // Extra 自定义属性
 func (c EntityPTCreator) Extra(extra map[string]any) EntityPTCreator {
 	for k, v := range extra {
@@ -84,6 +108,12 @@ func (c EntityPTCreator) Extra(extra map[string]any) EntityPTCreator {
 	return c
 }
 
+// Migration 实体在线升级迁移函数，运行时开启在线升级后，实体原型重声明时将使用此函数迁移存量实体的数据
+func (c EntityPTCreator) Migration(fun ec.EntityMigration) EntityPTCreator {
+	c.atti.Migration = fun
+	return c
+}
+
 // AddComponent 添加组件
 func (c EntityPTCreator) AddComponent(comp any, name ...string) EntityPTCreator {
 	switch v := comp.(type) {
@@ -95,6 +125,25 @@ func (c EntityPTCreator) AddComponent(comp any, name ...string) EntityPTCreator
 	return c
 }
 
+// RemoveComponent 删除基础实体原型中的组件
+func (c EntityPTCreator) RemoveComponent(names ...string) EntityPTCreator {
+	c.atti = c.atti.RemoveComponents(names...)
+	return c
+}
+
+// AddChild 添加子实体，支持子实体原型名称或子实体原型属性，创建实体时子实体将一同创建
+func (c EntityPTCreator) AddChild(child any) EntityPTCreator {
+	switch v := child.(type) {
+	case pt.ChildAttribute, *pt.ChildAttribute:
+		c.comps = append(c.comps, v)
+	case string:
+		c.comps = append(c.comps, pt.Child(v))
+	default:
+		exception.Panicf("%w: %w: invalid child type: %T", ErrCore, ErrArgs, child)
+	}
+	return c
+}
+
 // Declare 声明实体原型
 func (c EntityPTCreator) Declare() {
 	if c.svcCtx == nil {
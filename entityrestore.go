@@ -0,0 +1,131 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/ec/pt"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/runtime"
+	"git.golaxy.org/core/service"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/uid"
+	"reflect"
+)
+
+// RestoreEntity 使用快照恢复实体与其子孙实体，实体通过实体原型库重建，并在唤醒（Awake）前恢复组件状态，任意实体恢复失败时，已恢复的实体将被销毁
+func RestoreEntity(provider ictx.CurrentContextProvider, snapshot *ec.EntitySnapshot, parentId uid.Id) (ec.Entity, error) {
+	if provider == nil {
+		exception.Panicf("%w: %w: provider is nil", ErrCore, ErrArgs)
+	}
+
+	if snapshot == nil {
+		exception.Panicf("%w: %w: snapshot is nil", ErrCore, ErrArgs)
+	}
+
+	return restoreEntity(runtime.Current(provider), snapshot, parentId)
+}
+
+func restoreEntity(rtCtx runtime.Context, snapshot *ec.EntitySnapshot, parentId uid.Id) (ec.Entity, error) {
+	entityLib := service.Current(rtCtx).GetEntityLib()
+
+	entityPT, ok := entityLib.Get(snapshot.Prototype)
+	if !ok {
+		return nil, fmt.Errorf("%w: entity prototype %q not declared", ErrCore, snapshot.Prototype)
+	}
+
+	entity := entityPT.Construct(ec.With.PersistId(snapshot.PersistId), ec.With.Meta(snapshot.Meta.Clone()))
+
+	compKeys := make([]_ComponentKey, 0, len(snapshot.Components))
+	for i := range snapshot.Components {
+		compKeys = append(compKeys, _ComponentKey{name: snapshot.Components[i].Name, prototype: snapshot.Components[i].Prototype})
+	}
+
+	if err := complementComponents(entityLib, entity, compKeys); err != nil {
+		return nil, err
+	}
+
+	if err := ec.ApplyEntitySnapshot(entity, snapshot); err != nil {
+		return nil, err
+	}
+
+	if parentId.IsNil() {
+		if err := rtCtx.GetEntityManager().AddEntity(entity); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := rtCtx.GetEntityTree().AddNode(entity, parentId); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, childSnapshot := range snapshot.Children {
+		if childSnapshot == nil {
+			continue
+		}
+		if _, err := restoreEntity(rtCtx, childSnapshot, entity.GetId()); err != nil {
+			rtCtx.GetEntityManager().RemoveEntity(entity.GetId())
+			return nil, err
+		}
+	}
+
+	return entity, nil
+}
+
+type _ComponentKey struct {
+	name, prototype string
+	instanceRT      reflect.Type // 未通过原型创建的组件，使用此类型直接创建
+}
+
+// complementComponents 按名称与原型依次匹配实体中的组件，补充实体中缺少的组件
+func complementComponents(entityLib pt.EntityLib, entity ec.Entity, compKeys []_ComponentKey) error {
+	comps := entity.GetComponents()
+
+	for _, compKey := range compKeys {
+		idx := -1
+		for j, comp := range comps {
+			if comp != nil && comp.GetName() == compKey.name && comp.GetBuiltin().PT.Prototype() == compKey.prototype {
+				idx = j
+				break
+			}
+		}
+		if idx >= 0 {
+			comps[idx] = nil
+			continue
+		}
+
+		var comp ec.Component
+
+		if compPT, ok := entityLib.GetComponentLib().Get(compKey.prototype); ok {
+			comp = compPT.Construct()
+		} else if compKey.prototype == "" && compKey.instanceRT != nil {
+			comp = reflect.New(compKey.instanceRT).Interface().(ec.Component)
+		} else {
+			return fmt.Errorf("%w: component prototype %q not declared", ErrCore, compKey.prototype)
+		}
+
+		if err := entity.AddComponent(compKey.name, comp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package ictx
+
+import (
+	"git.golaxy.org/core/utils/exception"
+)
+
+// ReportError 将错误附带调用堆栈写入上下文的error channel，未设置或已写满时丢弃
+func ReportError(ctx Context, err error) {
+	reportError := ctx.GetReportError()
+	if reportError == nil {
+		return
+	}
+	select {
+	case reportError <- exception.TraceStack(err):
+	default:
+	}
+}
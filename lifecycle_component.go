@@ -19,6 +19,8 @@
 
 package core
 
+import "git.golaxy.org/core/ec/pt"
+
 // LifecycleComponentAwake 组件的生命周期进入唤醒（Awake）时的回调，组件实现此接口即可使用
 type LifecycleComponentAwake interface {
 	Awake()
@@ -50,7 +52,20 @@ type LifecycleComponentOnDisable interface {
 	OnDisable()
 }
 
+// LifecycleComponentReset 实体原型开启对象池时，组件随实体回收至对象池前的回调，组件实现此接口可以自行重置状态，未实现时组件将被零值化
+type LifecycleComponentReset = pt.ComponentReset
+
 // LifecycleComponentDispose 组件的生命周期进入死亡（Death）时的回调，组件实现此接口即可使用
 type LifecycleComponentDispose interface {
 	Dispose()
 }
+
+// LifecycleComponentAwakeE 组件的生命周期进入唤醒（Awake）时的回调，实体激活过程中返回错误时将回滚并删除实体，实体激活后添加的组件返回错误时将删除组件，同时实现 LifecycleComponentAwake 时先调用 Awake()，组件实现此接口即可使用
+type LifecycleComponentAwakeE interface {
+	AwakeE() error
+}
+
+// LifecycleComponentStartE 组件的生命周期进入开始（Start）时的回调，实体激活过程中返回错误时将回滚并删除实体，实体激活后添加的组件返回错误时将删除组件，同时实现 LifecycleComponentStart 时先调用 Start()，组件实现此接口即可使用
+type LifecycleComponentStartE interface {
+	StartE() error
+}
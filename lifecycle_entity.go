@@ -19,6 +19,8 @@
 
 package core
 
+import "git.golaxy.org/core/ec/pt"
+
 // LifecycleEntityAwake 实体的生命周期进入唤醒（Awake）时的回调，实体实现此接口即可使用
 type LifecycleEntityAwake interface {
 	Awake()
@@ -44,3 +46,16 @@ type LifecycleEntityShut interface {
 type LifecycleEntityDispose interface {
 	Dispose()
 }
+
+// LifecycleEntityReset 实体原型开启对象池时，实体回收至对象池前的回调，实体实现此接口可以自行重置状态，未实现时实体将被零值化
+type LifecycleEntityReset = pt.EntityReset
+
+// LifecycleEntityAwakeE 实体的生命周期进入唤醒（Awake）时的回调，返回错误时将反序回滚已唤醒的组件并删除实体，同时实现 LifecycleEntityAwake 时先调用 Awake()，实体实现此接口即可使用
+type LifecycleEntityAwakeE interface {
+	AwakeE() error
+}
+
+// LifecycleEntityStartE 实体的生命周期进入开始（Start）时的回调，返回错误时将反序回滚已唤醒的组件并删除实体，同时实现 LifecycleEntityStart 时先调用 Start()，实体实现此接口即可使用
+type LifecycleEntityStartE interface {
+	StartE() error
+}
@@ -20,6 +20,7 @@
 package core
 
 import (
+	"fmt"
 	"git.golaxy.org/core/ec"
 	"git.golaxy.org/core/event"
 	"git.golaxy.org/core/internal/ictx"
@@ -66,6 +67,7 @@ type iRuntime interface {
 }
 
 const (
+	tagForRuntimeObserveEntityUpdate           = "runtime_observe_entity_update"
 	tagForRuntimeObserveComponentEnableChanged = "runtime_observe_component_enable_changed"
 	tagForRuntimeObserveComponentUpdate        = "runtime_observe_component_update"
 )
@@ -131,9 +133,17 @@ type RuntimeBehavior struct {
 	handleEventEntityManagerEntityFirstTouchComponent runtime.EventEntityManagerEntityFirstTouchComponent
 	handleEventEntityManagerEntityAddComponents       runtime.EventEntityManagerEntityAddComponents
 	handleEventEntityManagerEntityRemoveComponent     runtime.EventEntityManagerEntityRemoveComponent
+	handleEventEntityManagerEntityReplaceComponent    runtime.EventEntityManagerEntityReplaceComponent
 	handleEventEntityDestroySelf                      ec.EventEntityDestroySelf
+	handleEventEntityDestroyDeferred                  ec.EventEntityDestroyDeferred
+	handleEventEntityActiveChanged                    ec.EventEntityActiveChanged
 	handleEventComponentEnableChanged                 ec.EventComponentEnableChanged
 	handleEventComponentDestroySelf                   ec.EventComponentDestroySelf
+	pendingDestroyList                                []ec.EntityHandle
+	pendingDestroyIndex                               map[ec.EntityHandle]struct{}
+	deferredDestroyTimers                             _DeferredDestroyTimers
+	pendingReleaseList                                []ec.Entity
+	unwatchEntityPT                                   func()
 }
 
 // GetCurrentContext 获取当前上下文
@@ -168,6 +178,7 @@ func (rt *RuntimeBehavior) init(rtCtx runtime.Context, opts RuntimeOptions) {
 	}
 
 	rt.processQueue = make(chan _Task, rt.opts.ProcessQueueCapacity)
+	rt.pendingDestroyIndex = map[ec.EntityHandle]struct{}{}
 
 	runtime.UnsafeContext(rtCtx).SetFrame(rt.opts.Frame)
 	runtime.UnsafeContext(rtCtx).SetCallee(rt.opts.InstanceFace.Iface)
@@ -181,7 +192,10 @@ func (rt *RuntimeBehavior) init(rtCtx runtime.Context, opts RuntimeOptions) {
 	rt.handleEventEntityManagerEntityFirstTouchComponent = runtime.HandleEventEntityManagerEntityFirstTouchComponent(rt.onEntityManagerEntityFirstTouchComponent)
 	rt.handleEventEntityManagerEntityAddComponents = runtime.HandleEventEntityManagerEntityAddComponents(rt.onEntityManagerEntityAddComponents)
 	rt.handleEventEntityManagerEntityRemoveComponent = runtime.HandleEventEntityManagerEntityRemoveComponent(rt.onEntityManagerEntityRemoveComponent)
+	rt.handleEventEntityManagerEntityReplaceComponent = runtime.HandleEventEntityManagerEntityReplaceComponent(rt.onEntityManagerEntityReplaceComponent)
 	rt.handleEventEntityDestroySelf = ec.HandleEventEntityDestroySelf(rt.onEntityDestroySelf)
+	rt.handleEventEntityDestroyDeferred = ec.HandleEventEntityDestroyDeferred(rt.onEntityDestroyDeferred)
+	rt.handleEventEntityActiveChanged = ec.HandleEventEntityActiveChanged(rt.onEntityActiveChanged)
 	rt.handleEventComponentEnableChanged = ec.HandleEventComponentEnableChanged(rt.onComponentEnableChanged)
 	rt.handleEventComponentDestroySelf = ec.HandleEventComponentDestroySelf(rt.onComponentDestroySelf)
 
@@ -224,7 +238,10 @@ func (rt *RuntimeBehavior) onEntityManagerEntityFirstTouchComponent(entityManage
 	}
 
 	rt.observeComponentDestroySelf(component)
-	rt.awakeComponent(component)
+
+	if err := rt.awakeComponent(component); err != nil {
+		rt.failComponent(component, err)
+	}
 }
 
 // onEntityManagerEntityAddComponents 事件处理器：实体管理器中的实体添加组件
@@ -245,7 +262,9 @@ func (rt *RuntimeBehavior) onEntityManagerEntityAddComponents(entityManager runt
 				if entity.GetState() != state {
 					return
 				}
-				rt.awakeComponent(components[i])
+				if err := rt.awakeComponent(components[i]); err != nil {
+					rt.failComponent(components[i], err)
+				}
 			}
 		}) {
 			return
@@ -267,7 +286,9 @@ func (rt *RuntimeBehavior) onEntityManagerEntityAddComponents(entityManager runt
 				if entity.GetState() != state {
 					return
 				}
-				rt.startComponent(components[i])
+				if err := rt.startComponent(components[i]); err != nil {
+					rt.failComponent(components[i], err)
+				}
 			}
 		}) {
 			return
@@ -319,17 +340,139 @@ func (rt *RuntimeBehavior) onEntityManagerEntityRemoveComponent(entityManager ru
 	}
 }
 
+// onEntityManagerEntityReplaceComponent 事件处理器：实体管理器中的实体替换组件，新旧组件的生命周期交替推进（旧组件关闭、新组件唤醒、旧组件禁用、新组件启用、旧组件销毁、新组件开始），
+// 保证同名组件在替换过程中始终可用
+func (rt *RuntimeBehavior) onEntityManagerEntityReplaceComponent(entityManager runtime.EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) {
+	if entity.GetState() < ec.EntityState_Awake || entity.GetState() > ec.EntityState_Alive {
+		return
+	}
+
+	rt.observeComponentDestroySelf(newComponent)
+
+	if oldComponent.GetState() == ec.ComponentState_Detach {
+		if !ec.UnsafeComponent(oldComponent).GetProcessedStateBits().Is(int8(ec.ComponentState_Awake)) {
+			ec.UnsafeComponent(oldComponent).SetState(ec.ComponentState_Destroyed)
+		} else if ec.UnsafeComponent(oldComponent).GetProcessedStateBits().Is(int8(ec.ComponentState_Alive)) {
+			ec.UnsafeComponent(oldComponent).SetState(ec.ComponentState_Shut)
+		} else {
+			ec.UnsafeComponent(oldComponent).SetState(ec.ComponentState_Disable)
+		}
+	}
+
+	{
+		caller := makeEntityLifecycleCaller(entity)
+
+		if !caller.Call(func(state ec.EntityState) {
+			rt.shutComponent(oldComponent)
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			if err := rt.awakeComponent(newComponent); err != nil {
+				rt.failComponent(newComponent, err)
+			}
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			rt.disableDeathComponent(oldComponent)
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			rt.enableAwokeComponent(newComponent)
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			rt.disposeComponent(oldComponent)
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			if err := rt.startComponent(newComponent); err != nil {
+				rt.failComponent(newComponent, err)
+			}
+		}) {
+			return
+		}
+	}
+}
+
 // onEntityDestroySelf 事件处理器：实体销毁自身
 func (rt *RuntimeBehavior) onEntityDestroySelf(entity ec.Entity) {
 	rt.ctx.GetEntityManager().RemoveEntity(entity.GetId())
 }
 
+// onEntityActiveChanged 事件处理器：实体在实体树中的激活状态改变
+func (rt *RuntimeBehavior) onEntityActiveChanged(entity ec.Entity, active bool) {
+	if entity.GetActiveInHierarchy() != active {
+		return
+	}
+
+	if entity.GetState() < ec.EntityState_Awake || entity.GetState() > ec.EntityState_Alive {
+		return
+	}
+
+	caller := makeEntityLifecycleCaller(entity)
+
+	if active {
+		if !caller.Call(func(state ec.EntityState) {
+			rt.observeEntityUpdate(entity)
+
+			entity.RangeComponents(func(comp ec.Component) bool {
+				if comp.GetState() == ec.ComponentState_Idle {
+					rt.enableComponent(comp)
+				}
+				return entity.GetState() == state && entity.GetActiveInHierarchy()
+			})
+		}) {
+			return
+		}
+
+		if !caller.Call(func(state ec.EntityState) {
+			entity.RangeComponents(func(comp ec.Component) bool {
+				if err := rt.startComponent(comp); err != nil {
+					rt.failComponent(comp, err)
+				}
+				return entity.GetState() == state && entity.GetActiveInHierarchy()
+			})
+		}) {
+			return
+		}
+
+	} else {
+		if !caller.Call(func(state ec.EntityState) {
+			rt.unobserveEntityUpdate(entity)
+
+			entity.RangeComponents(func(comp ec.Component) bool {
+				switch comp.GetState() {
+				case ec.ComponentState_Start, ec.ComponentState_Alive:
+					rt.disableComponent(comp)
+				}
+				return entity.GetState() == state && !entity.GetActiveInHierarchy()
+			})
+		}) {
+			return
+		}
+	}
+}
+
 // onComponentEnableChanged 事件处理器：组件启用状态改变
 func (rt *RuntimeBehavior) onComponentEnableChanged(comp ec.Component, enable bool) {
 	if comp.GetEnable() != enable {
 		return
 	}
 
+	if !comp.GetEntity().GetActiveInHierarchy() {
+		return
+	}
+
 	caller := makeEntityLifecycleCaller(comp.GetEntity())
 
 	if enable {
@@ -340,7 +483,9 @@ func (rt *RuntimeBehavior) onComponentEnableChanged(comp ec.Component, enable bo
 		}
 
 		if !caller.Call(func(ec.EntityState) {
-			rt.startComponent(comp)
+			if err := rt.startComponent(comp); err != nil {
+				rt.failComponent(comp, err)
+			}
 		}) {
 			return
 		}
@@ -364,15 +509,13 @@ func (rt *RuntimeBehavior) observeEntity(entity ec.Entity) {
 		return
 	}
 
-	if cb, ok := entity.(LifecycleEntityUpdate); ok {
-		event.Bind[LifecycleEntityUpdate](&rt.eventUpdate, cb)
-	}
-
-	if cb, ok := entity.(LifecycleEntityLateUpdate); ok {
-		event.Bind[LifecycleEntityLateUpdate](&rt.eventLateUpdate, cb)
+	if entity.GetActiveInHierarchy() {
+		rt.observeEntityUpdate(entity)
 	}
 
 	ec.BindEventEntityDestroySelf(entity, rt.handleEventEntityDestroySelf)
+	ec.BindEventEntityDestroyDeferred(entity, rt.handleEventEntityDestroyDeferred)
+	ec.BindEventEntityActiveChanged(entity, rt.handleEventEntityActiveChanged)
 
 	entity.RangeComponents(func(comp ec.Component) bool {
 		rt.observeComponentDestroySelf(comp)
@@ -382,6 +525,24 @@ func (rt *RuntimeBehavior) observeEntity(entity ec.Entity) {
 	ec.UnsafeEntity(entity).SetState(ec.EntityState_Awake)
 }
 
+func (rt *RuntimeBehavior) observeEntityUpdate(entity ec.Entity) {
+	var hooks []event.Hook
+
+	if cb, ok := entity.(LifecycleEntityUpdate); ok {
+		hooks = append(hooks, event.Bind[LifecycleEntityUpdate](&rt.eventUpdate, cb))
+	}
+
+	if cb, ok := entity.(LifecycleEntityLateUpdate); ok {
+		hooks = append(hooks, event.Bind[LifecycleEntityLateUpdate](&rt.eventLateUpdate, cb))
+	}
+
+	entity.ManagedAddTagHooks(tagForRuntimeObserveEntityUpdate, hooks...)
+}
+
+func (rt *RuntimeBehavior) unobserveEntityUpdate(entity ec.Entity) {
+	entity.ManagedCleanTagHooks(tagForRuntimeObserveEntityUpdate)
+}
+
 func (rt *RuntimeBehavior) observeComponentDestroySelf(comp ec.Component) {
 	if comp.GetState() != ec.ComponentState_Attach {
 		return
@@ -425,6 +586,8 @@ func (rt *RuntimeBehavior) activateEntity(entity ec.Entity) {
 		return
 	}
 
+	var err error
+
 	{
 		caller := makeEntityLifecycleCaller(entity)
 
@@ -432,19 +595,32 @@ func (rt *RuntimeBehavior) activateEntity(entity ec.Entity) {
 			if cb, ok := entity.(LifecycleEntityAwake); ok {
 				generic.CastAction0(cb.Awake).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 			}
+			if cb, ok := entity.(LifecycleEntityAwakeE); ok {
+				err = rt.callLifecycleE(cb.AwakeE)
+			}
 		}) {
 			return
 		}
 
+		if err != nil {
+			rt.rollbackEntity(entity, err)
+			return
+		}
+
 		if !caller.Call(func(state ec.EntityState) {
 			entity.RangeComponents(func(comp ec.Component) bool {
-				rt.awakeComponent(comp)
-				return entity.GetState() == state
+				err = rt.awakeComponent(comp)
+				return err == nil && entity.GetState() == state
 			})
 		}) {
 			return
 		}
 
+		if err != nil {
+			rt.rollbackEntity(entity, err)
+			return
+		}
+
 		if !caller.Call(func(state ec.EntityState) {
 			entity.RangeComponents(func(comp ec.Component) bool {
 				rt.enableAwokeComponent(comp)
@@ -462,20 +638,33 @@ func (rt *RuntimeBehavior) activateEntity(entity ec.Entity) {
 
 		if !caller.Call(func(state ec.EntityState) {
 			entity.RangeComponents(func(comp ec.Component) bool {
-				rt.startComponent(comp)
-				return entity.GetState() == state
+				err = rt.startComponent(comp)
+				return err == nil && entity.GetState() == state
 			})
 		}) {
 			return
 		}
 
+		if err != nil {
+			rt.rollbackEntity(entity, err)
+			return
+		}
+
 		if !caller.Call(func(ec.EntityState) {
 			if cb, ok := entity.(LifecycleEntityStart); ok {
 				generic.CastAction0(cb.Start).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 			}
+			if cb, ok := entity.(LifecycleEntityStartE); ok {
+				err = rt.callLifecycleE(cb.StartE)
+			}
 		}) {
 			return
 		}
+
+		if err != nil {
+			rt.rollbackEntity(entity, err)
+			return
+		}
 	}
 
 	ec.UnsafeEntity(entity).SetState(ec.EntityState_Alive)
@@ -527,13 +716,17 @@ func (rt *RuntimeBehavior) deactivateEntity(entity ec.Entity) {
 	}
 
 	ec.UnsafeEntity(entity).SetState(ec.EntityState_Destroyed)
+
+	rt.pushPendingRelease(entity)
 }
 
-func (rt *RuntimeBehavior) awakeComponent(comp ec.Component) {
+func (rt *RuntimeBehavior) awakeComponent(comp ec.Component) error {
 	if comp.GetState() != ec.ComponentState_Awake {
-		return
+		return nil
 	}
 
+	var err error
+
 	{
 		caller := makeComponentLifecycleCaller(comp)
 
@@ -541,12 +734,20 @@ func (rt *RuntimeBehavior) awakeComponent(comp ec.Component) {
 			if cb, ok := comp.(LifecycleComponentAwake); ok {
 				generic.CastAction0(cb.Awake).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 			}
+			if cb, ok := comp.(LifecycleComponentAwakeE); ok {
+				err = rt.callLifecycleE(cb.AwakeE)
+			}
 		}) {
-			return
+			return nil
 		}
 	}
 
+	if err != nil {
+		return fmt.Errorf("%w: component %q awake failed, %w", ErrRuntime, comp.GetName(), err)
+	}
+
 	ec.UnsafeComponent(comp).SetState(ec.ComponentState_Enable)
+	return nil
 }
 
 func (rt *RuntimeBehavior) enableAwokeComponent(comp ec.Component) {
@@ -554,7 +755,7 @@ func (rt *RuntimeBehavior) enableAwokeComponent(comp ec.Component) {
 		return
 	}
 
-	if !comp.GetEnable() {
+	if !rt.isComponentEnabled(comp) {
 		rt.observeComponentEnableChanged(comp)
 		ec.UnsafeComponent(comp).SetState(ec.ComponentState_Idle)
 		return
@@ -572,7 +773,7 @@ func (rt *RuntimeBehavior) enableAwokeComponent(comp ec.Component) {
 		}
 	}
 
-	if !comp.GetEnable() {
+	if !rt.isComponentEnabled(comp) {
 		rt.observeComponentEnableChanged(comp)
 		ec.UnsafeComponent(comp).SetState(ec.ComponentState_Idle)
 		return
@@ -588,11 +789,13 @@ func (rt *RuntimeBehavior) enableAwokeComponent(comp ec.Component) {
 	}
 }
 
-func (rt *RuntimeBehavior) startComponent(comp ec.Component) {
+func (rt *RuntimeBehavior) startComponent(comp ec.Component) error {
 	if comp.GetState() != ec.ComponentState_Start {
-		return
+		return nil
 	}
 
+	var err error
+
 	{
 		caller := makeComponentLifecycleCaller(comp)
 
@@ -600,12 +803,20 @@ func (rt *RuntimeBehavior) startComponent(comp ec.Component) {
 			if cb, ok := comp.(LifecycleComponentStart); ok {
 				generic.CastAction0(cb.Start).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 			}
+			if cb, ok := comp.(LifecycleComponentStartE); ok {
+				err = rt.callLifecycleE(cb.StartE)
+			}
 		}) {
-			return
+			return nil
 		}
 	}
 
+	if err != nil {
+		return fmt.Errorf("%w: component %q start failed, %w", ErrRuntime, comp.GetName(), err)
+	}
+
 	ec.UnsafeComponent(comp).SetState(ec.ComponentState_Alive)
+	return nil
 }
 
 func (rt *RuntimeBehavior) shutComponent(comp ec.Component) {
@@ -636,7 +847,7 @@ func (rt *RuntimeBehavior) disableDeathComponent(comp ec.Component) {
 	rt.unobserveComponentEnableChanged(comp)
 	rt.unobserveComponentUpdate(comp)
 
-	if comp.GetEnable() && ec.UnsafeComponent(comp).GetProcessedStateBits().Is(int8(ec.ComponentState_Start)) {
+	if rt.isComponentEnabled(comp) && ec.UnsafeComponent(comp).GetProcessedStateBits().Is(int8(ec.ComponentState_Start)) {
 		caller := makeComponentLifecycleCaller(comp)
 
 		if !caller.Call(func(ec.ComponentState) {
@@ -672,7 +883,7 @@ func (rt *RuntimeBehavior) disposeComponent(comp ec.Component) {
 }
 
 func (rt *RuntimeBehavior) enableComponent(comp ec.Component) {
-	if !comp.GetEnable() {
+	if !rt.isComponentEnabled(comp) {
 		return
 	}
 
@@ -688,7 +899,7 @@ func (rt *RuntimeBehavior) enableComponent(comp ec.Component) {
 		}
 	}
 
-	if !comp.GetEnable() {
+	if !rt.isComponentEnabled(comp) {
 		return
 	}
 
@@ -706,7 +917,7 @@ func (rt *RuntimeBehavior) enableComponent(comp ec.Component) {
 }
 
 func (rt *RuntimeBehavior) disableComponent(comp ec.Component) {
-	if comp.GetEnable() {
+	if rt.isComponentEnabled(comp) {
 		return
 	}
 
@@ -716,7 +927,7 @@ func (rt *RuntimeBehavior) disableComponent(comp ec.Component) {
 		generic.CastAction0(cb.OnDisable).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 	}
 
-	if comp.GetEnable() {
+	if rt.isComponentEnabled(comp) {
 		return
 	}
 
@@ -726,3 +937,7 @@ func (rt *RuntimeBehavior) disableComponent(comp ec.Component) {
 
 	ec.UnsafeComponent(comp).SetState(ec.ComponentState_Idle)
 }
+
+func (rt *RuntimeBehavior) isComponentEnabled(comp ec.Component) bool {
+	return comp.GetEnable() && comp.GetEntity().GetActiveInHierarchy()
+}
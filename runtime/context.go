@@ -77,6 +77,12 @@ type Context interface {
 	GetEntityManager() EntityManager
 	// GetEntityTree 获取实体树
 	GetEntityTree() EntityTree
+	// GetEntityRelations 获取实体关系
+	GetEntityRelations() EntityRelations
+	// GetEntityGroups 获取实体分组
+	GetEntityGroups() EntityGroups
+	// GetEntityDirtyTracker 获取脏实体追踪器
+	GetEntityDirtyTracker() EntityDirtyTracker
 	// ActivateEvent 启用事件
 	ActivateEvent(event event.IEventCtrl, recursion event.EventRecursion)
 	// ManagedAddHooks 托管事件钩子（event.Hook），在运行时停止时自动解绑定
@@ -143,6 +149,21 @@ func (ctx *ContextBehavior) GetEntityTree() EntityTree {
 	return &ctx.entityManager
 }
 
+// GetEntityRelations 获取实体关系
+func (ctx *ContextBehavior) GetEntityRelations() EntityRelations {
+	return &ctx.entityManager
+}
+
+// GetEntityGroups 获取实体分组
+func (ctx *ContextBehavior) GetEntityGroups() EntityGroups {
+	return &ctx.entityManager
+}
+
+// GetEntityDirtyTracker 获取脏实体追踪器
+func (ctx *ContextBehavior) GetEntityDirtyTracker() EntityDirtyTracker {
+	return &ctx.entityManager
+}
+
 // ActivateEvent 启用事件
 func (ctx *ContextBehavior) ActivateEvent(event event.IEventCtrl, recursion event.EventRecursion) {
 	if event == nil {
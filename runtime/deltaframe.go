@@ -0,0 +1,202 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"git.golaxy.org/core/utils/uid"
+)
+
+// PropertyDelta 组件属性增量，属性值使用JSON编码
+type PropertyDelta struct {
+	Name  string          `json:"name"`  // 属性名称
+	Value json.RawMessage `json:"value"` // 属性值
+}
+
+// ComponentDelta 组件增量
+type ComponentDelta struct {
+	Id         uid.Id          `json:"id"`                   // 组件Id
+	Name       string          `json:"name"`                 // 组件名称
+	Prototype  string          `json:"prototype,omitempty"`  // 组件原型
+	Properties []PropertyDelta `json:"properties,omitempty"` // 属性增量
+}
+
+// EntityDelta 实体增量
+type EntityDelta struct {
+	Op         DeltaOp          `json:"op"`                   // 增量操作类型
+	EntityId   uid.Id           `json:"entity_id"`            // 实体Id
+	Prototype  string           `json:"prototype,omitempty"`  // 实体原型，只在生成实体时填写
+	Components []ComponentDelta `json:"components,omitempty"` // 组件增量
+}
+
+// DeltaFrame 增量帧，包含一帧内观察集合中实体的所有增量，按发生顺序排列，支持JSON与紧凑的二进制（MarshalBinary）两种编码
+type DeltaFrame struct {
+	Seq    int64         `json:"seq"`    // 发布序号，从1开始
+	Frame  int64         `json:"frame"`  // 帧号，无帧模式下为0
+	Deltas []EntityDelta `json:"deltas"` // 实体增量
+}
+
+const deltaFrameBinaryVersion = 1
+
+// MarshalBinary 编码为紧凑的二进制格式，整数使用varint编码，字符串与属性值使用长度前缀编码
+func (f *DeltaFrame) MarshalBinary() ([]byte, error) {
+	bs := make([]byte, 0, 64)
+	bs = append(bs, deltaFrameBinaryVersion)
+	bs = binary.AppendVarint(bs, f.Seq)
+	bs = binary.AppendVarint(bs, f.Frame)
+	bs = binary.AppendUvarint(bs, uint64(len(f.Deltas)))
+
+	for i := range f.Deltas {
+		delta := &f.Deltas[i]
+		bs = append(bs, byte(delta.Op))
+		bs = appendDeltaBytes(bs, []byte(delta.EntityId))
+		bs = appendDeltaBytes(bs, []byte(delta.Prototype))
+		bs = binary.AppendUvarint(bs, uint64(len(delta.Components)))
+
+		for j := range delta.Components {
+			comp := &delta.Components[j]
+			bs = appendDeltaBytes(bs, []byte(comp.Id))
+			bs = appendDeltaBytes(bs, []byte(comp.Name))
+			bs = appendDeltaBytes(bs, []byte(comp.Prototype))
+			bs = binary.AppendUvarint(bs, uint64(len(comp.Properties)))
+
+			for k := range comp.Properties {
+				property := &comp.Properties[k]
+				bs = appendDeltaBytes(bs, []byte(property.Name))
+				bs = appendDeltaBytes(bs, property.Value)
+			}
+		}
+	}
+
+	return bs, nil
+}
+
+// UnmarshalBinary 从二进制格式解码，解码结果不引用data，调用方可以复用data
+func (f *DeltaFrame) UnmarshalBinary(data []byte) error {
+	r := _DeltaReader{data: data}
+
+	if version := r.readByte(); r.err == nil && version != deltaFrameBinaryVersion {
+		return fmt.Errorf("%w: unsupported binary version %d", ErrDeltaStream, version)
+	}
+
+	frame := DeltaFrame{
+		Seq:   r.readVarint(),
+		Frame: r.readVarint(),
+	}
+
+	for range r.readLen() {
+		delta := EntityDelta{
+			Op:        DeltaOp(r.readByte()),
+			EntityId:  uid.Id(r.readBytes()),
+			Prototype: string(r.readBytes()),
+		}
+
+		for range r.readLen() {
+			comp := ComponentDelta{
+				Id:        uid.Id(r.readBytes()),
+				Name:      string(r.readBytes()),
+				Prototype: string(r.readBytes()),
+			}
+
+			for range r.readLen() {
+				comp.Properties = append(comp.Properties, PropertyDelta{
+					Name:  string(r.readBytes()),
+					Value: json.RawMessage(bytes.Clone(r.readBytes())),
+				})
+			}
+
+			delta.Components = append(delta.Components, comp)
+		}
+
+		frame.Deltas = append(frame.Deltas, delta)
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrDeltaStream, len(r.data))
+	}
+
+	*f = frame
+	return nil
+}
+
+func appendDeltaBytes(bs, v []byte) []byte {
+	bs = binary.AppendUvarint(bs, uint64(len(v)))
+	return append(bs, v...)
+}
+
+type _DeltaReader struct {
+	data []byte
+	err  error
+}
+
+func (r *_DeltaReader) fail() {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: binary data is corrupted", ErrDeltaStream)
+	}
+	r.data = nil
+}
+
+func (r *_DeltaReader) readByte() byte {
+	if len(r.data) < 1 {
+		r.fail()
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *_DeltaReader) readVarint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *_DeltaReader) readLen() int {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 || v > uint64(len(r.data)) {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return int(v)
+}
+
+func (r *_DeltaReader) readBytes() []byte {
+	l := r.readLen()
+	if r.err != nil || l > len(r.data) {
+		r.fail()
+		return nil
+	}
+	v := r.data[:l:l]
+	r.data = r.data[l:]
+	return v
+}
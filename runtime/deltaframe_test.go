@@ -0,0 +1,111 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testDeltaFrame() *DeltaFrame {
+	return &DeltaFrame{
+		Seq:   3,
+		Frame: -7,
+		Deltas: []EntityDelta{
+			{
+				Op:        DeltaOp_Spawn,
+				EntityId:  "e1",
+				Prototype: "player",
+				Components: []ComponentDelta{
+					{
+						Id:        "c1",
+						Name:      "Stats",
+						Prototype: "Stats",
+						Properties: []PropertyDelta{
+							{Name: "HP", Value: json.RawMessage(`100`)},
+							{Name: "Name", Value: json.RawMessage(`"bob"`)},
+						},
+					},
+					{Id: "c2", Name: "Tag"},
+				},
+			},
+			{
+				Op:       DeltaOp_Destroy,
+				EntityId: "e2",
+			},
+		},
+	}
+}
+
+func TestDeltaFrameBinaryRoundTrip(t *testing.T) {
+	frame := testDeltaFrame()
+
+	data, err := frame.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded DeltaFrame
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(frame, &decoded) {
+		t.Fatalf("decoded frame mismatch, want %+v, got %+v", frame, &decoded)
+	}
+
+	clear(data)
+
+	if !reflect.DeepEqual(frame, &decoded) {
+		t.Fatalf("decoded frame aliases input data, got %+v", &decoded)
+	}
+}
+
+func TestDeltaFrameBinaryCorrupted(t *testing.T) {
+	data, err := testDeltaFrame().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n := range len(data) {
+		var decoded DeltaFrame
+		if err := decoded.UnmarshalBinary(data[:n]); !errors.Is(err, ErrDeltaStream) {
+			t.Fatalf("truncated to %d bytes, want ErrDeltaStream, got %v", n, err)
+		}
+	}
+
+	cases := map[string][]byte{
+		"version":  append([]byte{deltaFrameBinaryVersion + 1}, data[1:]...),
+		"trailing": append(append([]byte{}, data...), 0),
+		"length":   {deltaFrameBinaryVersion, 0, 0, 0xff, 0xff, 0xff, 0xff, 0x0f},
+	}
+
+	for name, bs := range cases {
+		decoded := DeltaFrame{Seq: 42}
+		if err := decoded.UnmarshalBinary(bs); !errors.Is(err, ErrDeltaStream) {
+			t.Fatalf("%s: want ErrDeltaStream, got %v", name, err)
+		}
+		if decoded.Seq != 42 {
+			t.Fatalf("%s: frame modified on error", name)
+		}
+	}
+}
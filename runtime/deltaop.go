@@ -0,0 +1,31 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+//go:generate stringer -type DeltaOp
+package runtime
+
+// DeltaOp 增量操作类型
+type DeltaOp int8
+
+const (
+	DeltaOp_Spawn            DeltaOp = iota // 实体生成（或加入观察集合），包含所有组件与属性
+	DeltaOp_Destroy                         // 实体销毁（或离开观察集合）
+	DeltaOp_AddComponent                    // 添加组件，包含组件的所有属性
+	DeltaOp_RemoveComponent                 // 删除组件
+	DeltaOp_ChangeProperties                // 组件属性改变，只包含改变过的属性
+)
@@ -0,0 +1,337 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/event"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/iface"
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/core/utils/uid"
+)
+
+// NewDeltaStream 创建增量复制流，用于为观察集合中的实体生成逐帧的增量（生成、销毁、添加组件、删除组件、属性改变），
+// 增量帧在帧循环结束时刷新脏实体后发布（EventDeltaStreamPublish），无帧模式下需要自行调用Flush，只能在运行时的goroutine中使用
+func NewDeltaStream(ctx Context, settings ...option.Setting[DeltaStreamOptions]) DeltaStream {
+	if ctx == nil {
+		exception.Panicf("%w: %w: ctx is nil", ErrDeltaStream, exception.ErrArgs)
+	}
+
+	stream := &_DeltaStreamBehavior{}
+	stream.init(ctx, option.Make(With.DeltaStream.Default(), settings...))
+
+	return stream
+}
+
+// DeltaStream 增量复制流接口
+type DeltaStream interface {
+	ictx.CurrentContextProvider
+
+	// Observe 实体加入观察集合，实体需要在实体管理器中，加入时将记录实体生成增量
+	Observe(entityId uid.Id) error
+	// Unobserve 实体离开观察集合，离开时将记录实体销毁增量
+	Unobserve(entityId uid.Id) bool
+	// IsObserved 实体是否在观察集合中
+	IsObserved(entityId uid.Id) bool
+	// CountObserved 获取观察集合中的实体数量
+	CountObserved() int
+	// Flush 刷新脏实体并立即发布增量帧，无帧模式下需要自行调用
+	Flush()
+	// Close 关闭增量复制流，不再记录与发布增量
+	Close()
+
+	IDeltaStreamEventTab
+}
+
+type _DeltaStreamBehavior struct {
+	ctx      Context
+	options  DeltaStreamOptions
+	observed map[uid.Id]struct{}
+	pending  []EntityDelta
+	seq      int64
+	hooks    []event.Hook
+	closed   bool
+
+	deltaStreamEventTab
+}
+
+func (stream *_DeltaStreamBehavior) init(ctx Context, options DeltaStreamOptions) {
+	stream.ctx = ctx
+	stream.options = options
+	stream.observed = map[uid.Id]struct{}{}
+
+	ctx.ActivateEvent(&stream.deltaStreamEventTab, event.EventRecursion_Allow)
+
+	entityManager := ctx.GetEntityManager()
+
+	stream.hooks = append(stream.hooks,
+		BindEventEntityManagerAddEntity(entityManager, stream),
+		BindEventEntityManagerRemoveEntity(entityManager, stream),
+		BindEventEntityManagerEntityAddComponents(entityManager, stream),
+		BindEventEntityManagerEntityRemoveComponent(entityManager, stream),
+		BindEventEntityManagerEntityReplaceComponent(entityManager, stream),
+		BindEventEntityDirtyFlush(ctx.GetEntityDirtyTracker(), stream),
+	)
+
+	if stream.options.Filter != nil {
+		entityManager.RangeEntities(func(entity ec.Entity) bool {
+			if entity.GetState() <= ec.EntityState_Alive && stream.options.Filter.UnsafeCall(entity) {
+				stream.observe(entity)
+			}
+			return true
+		})
+	}
+}
+
+// GetCurrentContext 获取当前上下文
+func (stream *_DeltaStreamBehavior) GetCurrentContext() iface.Cache {
+	return stream.ctx.GetCurrentContext()
+}
+
+// GetConcurrentContext 获取多线程安全的上下文
+func (stream *_DeltaStreamBehavior) GetConcurrentContext() iface.Cache {
+	return stream.ctx.GetConcurrentContext()
+}
+
+// Observe 实体加入观察集合，实体需要在实体管理器中，加入时将记录实体生成增量
+func (stream *_DeltaStreamBehavior) Observe(entityId uid.Id) error {
+	if stream.closed {
+		return fmt.Errorf("%w: stream is closed", ErrDeltaStream)
+	}
+
+	entity, ok := stream.ctx.GetEntityManager().GetEntity(entityId)
+	if !ok {
+		return fmt.Errorf("%w: entity %q not exist", ErrDeltaStream, entityId)
+	}
+
+	if entity.GetState() > ec.EntityState_Alive {
+		return fmt.Errorf("%w: invalid entity %q state %q", ErrDeltaStream, entity.GetId(), entity.GetState())
+	}
+
+	stream.observe(entity)
+	return nil
+}
+
+// Unobserve 实体离开观察集合，离开时将记录实体销毁增量
+func (stream *_DeltaStreamBehavior) Unobserve(entityId uid.Id) bool {
+	if _, ok := stream.observed[entityId]; !ok {
+		return false
+	}
+
+	delete(stream.observed, entityId)
+
+	stream.pending = append(stream.pending, EntityDelta{
+		Op:       DeltaOp_Destroy,
+		EntityId: entityId,
+	})
+
+	return true
+}
+
+// IsObserved 实体是否在观察集合中
+func (stream *_DeltaStreamBehavior) IsObserved(entityId uid.Id) bool {
+	_, ok := stream.observed[entityId]
+	return ok
+}
+
+// CountObserved 获取观察集合中的实体数量
+func (stream *_DeltaStreamBehavior) CountObserved() int {
+	return len(stream.observed)
+}
+
+// Flush 刷新脏实体并立即发布增量帧，无帧模式下需要自行调用
+func (stream *_DeltaStreamBehavior) Flush() {
+	if stream.closed {
+		return
+	}
+	stream.ctx.GetEntityDirtyTracker().FlushDirty()
+}
+
+// Close 关闭增量复制流，不再记录与发布增量
+func (stream *_DeltaStreamBehavior) Close() {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+
+	event.Clean(stream.hooks)
+	stream.hooks = nil
+
+	stream.deltaStreamEventTab.Close()
+
+	stream.observed = nil
+	stream.pending = nil
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityManagerAddEntity(entityManager EntityManager, entity ec.Entity) {
+	if stream.options.Filter == nil || stream.IsObserved(entity.GetId()) {
+		return
+	}
+	if stream.options.Filter.UnsafeCall(entity) {
+		stream.observe(entity)
+	}
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityManagerRemoveEntity(entityManager EntityManager, entity ec.Entity) {
+	stream.Unobserve(entity.GetId())
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityManagerEntityAddComponents(entityManager EntityManager, entity ec.Entity, components []ec.Component) {
+	if !stream.IsObserved(entity.GetId()) {
+		return
+	}
+
+	delta := EntityDelta{
+		Op:       DeltaOp_AddComponent,
+		EntityId: entity.GetId(),
+	}
+
+	for _, comp := range components {
+		delta.Components = append(delta.Components, stream.makeComponentDelta(comp, nil))
+	}
+
+	stream.pending = append(stream.pending, delta)
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityManagerEntityRemoveComponent(entityManager EntityManager, entity ec.Entity, component ec.Component) {
+	if !stream.IsObserved(entity.GetId()) {
+		return
+	}
+
+	stream.pending = append(stream.pending, EntityDelta{
+		Op:       DeltaOp_RemoveComponent,
+		EntityId: entity.GetId(),
+		Components: []ComponentDelta{
+			{
+				Id:        component.GetId(),
+				Name:      component.GetName(),
+				Prototype: component.GetBuiltin().PT.Prototype(),
+			},
+		},
+	})
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityManagerEntityReplaceComponent(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) {
+	stream.OnEntityManagerEntityRemoveComponent(entityManager, entity, oldComponent)
+	stream.OnEntityManagerEntityAddComponents(entityManager, entity, []ec.Component{newComponent})
+}
+
+func (stream *_DeltaStreamBehavior) OnEntityDirtyFlush(entityDirtyTracker EntityDirtyTracker) {
+	entityDirtyTracker.RangeDirtyEntities(func(entity ec.Entity) bool {
+		if !stream.IsObserved(entity.GetId()) {
+			return true
+		}
+
+		delta := EntityDelta{
+			Op:       DeltaOp_ChangeProperties,
+			EntityId: entity.GetId(),
+		}
+
+		for _, comp := range entity.GetDirtyComponents() {
+			delta.Components = append(delta.Components, stream.makeComponentDelta(comp, comp.GetDirtyProperties()))
+		}
+
+		if len(delta.Components) > 0 {
+			stream.pending = append(stream.pending, delta)
+		}
+		return true
+	})
+
+	stream.publish()
+}
+
+func (stream *_DeltaStreamBehavior) observe(entity ec.Entity) {
+	if stream.IsObserved(entity.GetId()) {
+		return
+	}
+
+	stream.observed[entity.GetId()] = struct{}{}
+
+	delta := EntityDelta{
+		Op:        DeltaOp_Spawn,
+		EntityId:  entity.GetId(),
+		Prototype: entity.GetPT().Prototype(),
+	}
+
+	entity.RangeComponents(func(comp ec.Component) bool {
+		delta.Components = append(delta.Components, stream.makeComponentDelta(comp, nil))
+		return true
+	})
+
+	stream.pending = append(stream.pending, delta)
+}
+
+func (stream *_DeltaStreamBehavior) makeComponentDelta(comp ec.Component, dirtyProperties []string) ComponentDelta {
+	compDelta := ComponentDelta{
+		Id:        comp.GetId(),
+		Name:      comp.GetName(),
+		Prototype: comp.GetBuiltin().PT.Prototype(),
+	}
+
+	appendProperty := func(property ec.IProperty) {
+		value, err := json.Marshal(property.GetAny())
+		if err != nil {
+			ictx.ReportError(stream.ctx, fmt.Errorf("%w: marshal component %q property %q failed, %w", ErrDeltaStream, comp.GetId(), property.GetName(), err))
+			return
+		}
+		compDelta.Properties = append(compDelta.Properties, PropertyDelta{
+			Name:  property.GetName(),
+			Value: value,
+		})
+	}
+
+	if dirtyProperties == nil {
+		for _, property := range comp.GetProperties() {
+			appendProperty(property)
+		}
+	} else {
+		for _, name := range dirtyProperties {
+			if property, ok := comp.GetProperty(name); ok {
+				appendProperty(property)
+			}
+		}
+	}
+
+	return compDelta
+}
+
+func (stream *_DeltaStreamBehavior) publish() {
+	if len(stream.pending) <= 0 && !stream.options.PublishEmptyFrames {
+		return
+	}
+
+	stream.seq++
+
+	frame := &DeltaFrame{
+		Seq:    stream.seq,
+		Deltas: stream.pending,
+	}
+	stream.pending = nil
+
+	if f := stream.ctx.GetFrame(); f != nil {
+		frame.Frame = f.GetCurFrames()
+	}
+
+	_EmitEventDeltaStreamPublish(stream, stream, frame)
+}
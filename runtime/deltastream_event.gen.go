@@ -0,0 +1,72 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Code generated by eventc event; DO NOT EDIT.
+
+package runtime
+
+import (
+	event "git.golaxy.org/core/event"
+)
+
+type iAutoEventDeltaStreamPublish interface {
+	EventDeltaStreamPublish() event.IEvent
+}
+
+func BindEventDeltaStreamPublish(auto iAutoEventDeltaStreamPublish, subscriber EventDeltaStreamPublish, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventDeltaStreamPublish](auto.EventDeltaStreamPublish(), subscriber, priority...)
+}
+
+func _EmitEventDeltaStreamPublish(auto iAutoEventDeltaStreamPublish, deltaStream DeltaStream, frame *DeltaFrame) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventDeltaStreamPublish()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventDeltaStreamPublish](subscriber).OnDeltaStreamPublish(deltaStream, frame)
+		return true
+	})
+}
+
+func _EmitEventDeltaStreamPublishWithInterrupt(auto iAutoEventDeltaStreamPublish, interrupt func(deltaStream DeltaStream, frame *DeltaFrame) bool, deltaStream DeltaStream, frame *DeltaFrame) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventDeltaStreamPublish()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(deltaStream, frame) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventDeltaStreamPublish](subscriber).OnDeltaStreamPublish(deltaStream, frame)
+		return true
+	})
+}
+
+func HandleEventDeltaStreamPublish(fun func(deltaStream DeltaStream, frame *DeltaFrame)) EventDeltaStreamPublishHandler {
+	return EventDeltaStreamPublishHandler(fun)
+}
+
+type EventDeltaStreamPublishHandler func(deltaStream DeltaStream, frame *DeltaFrame)
+
+func (h EventDeltaStreamPublishHandler) OnDeltaStreamPublish(deltaStream DeltaStream, frame *DeltaFrame) {
+	h(deltaStream, frame)
+}
@@ -0,0 +1,78 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Code generated by eventc eventtab --name=deltaStreamEventTab; DO NOT EDIT.
+
+package runtime
+
+import (
+	event "git.golaxy.org/core/event"
+)
+
+type IDeltaStreamEventTab interface {
+	EventDeltaStreamPublish() event.IEvent
+}
+
+var (
+	_deltaStreamEventTabId = event.DeclareEventTabIdT[deltaStreamEventTab]()
+	EventDeltaStreamPublishId = _deltaStreamEventTabId + 0
+)
+
+type deltaStreamEventTab [1]event.Event
+
+func (eventTab *deltaStreamEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
+	(*eventTab)[0].Init(autoRecover, reportError, event.EventRecursion_Discard)
+}
+
+func (eventTab *deltaStreamEventTab) Open() {
+	for i := range *eventTab {
+		(*eventTab)[i].Open()
+	}
+}
+
+func (eventTab *deltaStreamEventTab) Close() {
+	for i := range *eventTab {
+		(*eventTab)[i].Close()
+	}
+}
+
+func (eventTab *deltaStreamEventTab) Clean() {
+	for i := range *eventTab {
+		(*eventTab)[i].Clean()
+	}
+}
+
+func (eventTab *deltaStreamEventTab) Ctrl() event.IEventCtrl {
+	return eventTab
+}
+
+func (eventTab *deltaStreamEventTab) Event(id uint64) event.IEvent {
+	if _deltaStreamEventTabId != id & 0xFFFFFFFF00000000 {
+		return nil
+	}
+	pos := id & 0xFFFFFFFF
+	if pos >= uint64(len(*eventTab)) {
+		return nil
+	}
+	return &(*eventTab)[pos]
+}
+
+func (eventTab *deltaStreamEventTab) EventDeltaStreamPublish() event.IEvent {
+	return &(*eventTab)[0]
+}
@@ -0,0 +1,56 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/option"
+)
+
+// DeltaStreamOptions 增量复制流的所有选项
+type DeltaStreamOptions struct {
+	Filter             generic.Func1[ec.Entity, bool] // 实体过滤器，实体添加至实体管理器时，返回true将自动加入观察集合，为nil时只观察通过Observe()加入的实体
+	PublishEmptyFrames bool                           // 是否发布不包含任何增量的空帧
+}
+
+type _DeltaStreamOption struct{}
+
+// Default 默认值
+func (_DeltaStreamOption) Default() option.Setting[DeltaStreamOptions] {
+	return func(o *DeltaStreamOptions) {
+		With.DeltaStream.Filter(nil)(o)
+		With.DeltaStream.PublishEmptyFrames(false)(o)
+	}
+}
+
+// Filter 实体过滤器，实体添加至实体管理器时，返回true将自动加入观察集合，为nil时只观察通过Observe()加入的实体
+func (_DeltaStreamOption) Filter(fun generic.Func1[ec.Entity, bool]) option.Setting[DeltaStreamOptions] {
+	return func(o *DeltaStreamOptions) {
+		o.Filter = fun
+	}
+}
+
+// PublishEmptyFrames 是否发布不包含任何增量的空帧
+func (_DeltaStreamOption) PublishEmptyFrames(b bool) option.Setting[DeltaStreamOptions] {
+	return func(o *DeltaStreamOptions) {
+		o.PublishEmptyFrames = b
+	}
+}
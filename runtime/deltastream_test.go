@@ -0,0 +1,138 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"git.golaxy.org/core"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/runtime"
+	"git.golaxy.org/core/service"
+)
+
+type deltaStats struct {
+	ec.ComponentBehavior
+	HP ec.Property[int]
+}
+
+type deltaTag struct {
+	ec.ComponentBehavior
+}
+
+func TestDeltaStreamFlush(t *testing.T) {
+	svcCtx := service.NewContext()
+	core.CreateEntityPT(svcCtx, "player").AddComponent(&deltaStats{}, "Stats").Declare()
+
+	rtCtx := runtime.NewContext(svcCtx)
+	rt := core.NewRuntime(rtCtx, core.With.Runtime.AutoRun(true))
+	defer func() { <-rt.Terminate() }()
+
+	var frames []*runtime.DeltaFrame
+
+	ret := <-rtCtx.CallVoidAsync(func(...any) {
+		stream := runtime.NewDeltaStream(rtCtx)
+		defer stream.Close()
+
+		runtime.BindEventDeltaStreamPublish(stream, runtime.HandleEventDeltaStreamPublish(func(stream runtime.DeltaStream, frame *runtime.DeltaFrame) {
+			frames = append(frames, frame)
+		}))
+
+		entity, err := core.CreateEntity(rtCtx, "player").Spawn()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := stream.Observe(entity.GetId()); err != nil {
+			t.Error(err)
+			return
+		}
+		stream.Flush()
+
+		entity.GetComponent("Stats").(*deltaStats).HP.Set(100)
+		stream.Flush()
+
+		if err := entity.AddComponent("Tag", &deltaTag{}); err != nil {
+			t.Error(err)
+			return
+		}
+		stream.Flush()
+
+		entity.RemoveComponent("Tag")
+		stream.Flush()
+
+		entity.DestroySelf()
+		stream.Flush()
+	})
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+
+	wantOps := [][]runtime.DeltaOp{
+		{runtime.DeltaOp_Spawn},
+		{runtime.DeltaOp_ChangeProperties},
+		{runtime.DeltaOp_AddComponent},
+		{runtime.DeltaOp_RemoveComponent},
+		{runtime.DeltaOp_Destroy},
+	}
+
+	if len(frames) != len(wantOps) {
+		t.Fatalf("want %d frames, got %d", len(wantOps), len(frames))
+	}
+
+	for i, frame := range frames {
+		if frame.Seq != int64(i+1) {
+			t.Errorf("frame %d: want seq %d, got %d", i, i+1, frame.Seq)
+		}
+		if frame.Frame != 0 {
+			t.Errorf("frame %d: want frame 0 in no-frame mode, got %d", i, frame.Frame)
+		}
+		if len(frame.Deltas) != len(wantOps[i]) {
+			t.Fatalf("frame %d: want %d deltas, got %d", i, len(wantOps[i]), len(frame.Deltas))
+		}
+		for j, delta := range frame.Deltas {
+			if delta.Op != wantOps[i][j] {
+				t.Errorf("frame %d delta %d: want op %s, got %s", i, j, wantOps[i][j], delta.Op)
+			}
+		}
+	}
+
+	spawn := frames[0].Deltas[0]
+	if spawn.Prototype != "player" || len(spawn.Components) != 1 || spawn.Components[0].Name != "Stats" {
+		t.Fatalf("unexpected spawn delta %+v", spawn)
+	}
+
+	change := frames[1].Deltas[0]
+	if len(change.Components) != 1 || len(change.Components[0].Properties) != 1 {
+		t.Fatalf("unexpected change delta %+v", change)
+	}
+	if property := change.Components[0].Properties[0]; property.Name != "HP" || string(property.Value) != "100" {
+		t.Fatalf("unexpected changed property %+v", property)
+	}
+
+	if add := frames[2].Deltas[0]; len(add.Components) != 1 || add.Components[0].Name != "Tag" {
+		t.Fatalf("unexpected add delta %+v", add)
+	}
+
+	if remove := frames[3].Deltas[0]; len(remove.Components) != 1 || remove.Components[0].Name != "Tag" {
+		t.Fatalf("unexpected remove delta %+v", remove)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/uid"
+)
+
+// EntityDirtyTracker 脏实体追踪器接口，组件属性（ec.Property）改变时，实体将记录至脏数据集合中，
+// 脏数据集合会在帧循环结束时（RunningStatus_FrameLoopEnd）先发送刷新事件（EventEntityDirtyFlush）再清理，无帧模式下需要自行调用FlushDirty
+type EntityDirtyTracker interface {
+	ictx.CurrentContextProvider
+
+	// RangeDirtyEntities 按首次改变顺序遍历本帧的脏实体
+	RangeDirtyEntities(fun generic.Func1[ec.Entity, bool])
+	// GetDirtyEntities 按首次改变顺序获取本帧的所有脏实体
+	GetDirtyEntities() []ec.Entity
+	// CountDirtyEntities 获取本帧的脏实体数量
+	CountDirtyEntities() int
+	// FlushDirty 发送刷新事件（EventEntityDirtyFlush）后清理脏数据集合
+	FlushDirty()
+
+	IEntityDirtyEventTab
+}
+
+// RangeDirtyEntities 按首次改变顺序遍历本帧的脏实体
+func (mgr *_EntityManagerBehavior) RangeDirtyEntities(fun generic.Func1[ec.Entity, bool]) {
+	entities := make([]ec.Entity, 0, mgr.dirtyEntities.Len())
+	mgr.dirtyEntities.Traversal(func(node *generic.Node[ec.Entity]) bool {
+		entities = append(entities, node.V)
+		return true
+	})
+
+	for _, entity := range entities {
+		if !mgr.isDirtyEntityValid(entity) {
+			continue
+		}
+		if !fun.UnsafeCall(entity) {
+			return
+		}
+	}
+}
+
+// GetDirtyEntities 按首次改变顺序获取本帧的所有脏实体
+func (mgr *_EntityManagerBehavior) GetDirtyEntities() []ec.Entity {
+	var entities []ec.Entity
+	mgr.RangeDirtyEntities(func(entity ec.Entity) bool {
+		entities = append(entities, entity)
+		return true
+	})
+	return entities
+}
+
+// CountDirtyEntities 获取本帧的脏实体数量
+func (mgr *_EntityManagerBehavior) CountDirtyEntities() int {
+	count := 0
+	mgr.dirtyEntities.Traversal(func(node *generic.Node[ec.Entity]) bool {
+		if mgr.isDirtyEntityValid(node.V) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// FlushDirty 发送刷新事件（EventEntityDirtyFlush）后清理脏数据集合
+func (mgr *_EntityManagerBehavior) FlushDirty() {
+	_EmitEventEntityDirtyFlush(mgr, mgr)
+
+	mgr.dirtyEntities.Traversal(func(node *generic.Node[ec.Entity]) bool {
+		ec.UnsafeEntity(node.V).ClearDirty()
+		return true
+	})
+	mgr.dirtyEntities = generic.List[ec.Entity]{}
+	clear(mgr.dirtyEntityIndex)
+}
+
+func (mgr *_EntityManagerBehavior) OnEntityDirty(entity ec.Entity) {
+	mgr.markEntityDirty(entity)
+}
+
+func (mgr *_EntityManagerBehavior) markEntityDirty(entity ec.Entity) {
+	if !entity.IsDirty() {
+		return
+	}
+	if _, ok := mgr.dirtyEntityIndex[entity.GetId()]; ok {
+		return
+	}
+	if mgr.dirtyEntityIndex == nil {
+		mgr.dirtyEntityIndex = map[uid.Id]*generic.Node[ec.Entity]{}
+	}
+	mgr.dirtyEntityIndex[entity.GetId()] = mgr.dirtyEntities.PushBack(entity)
+}
+
+func (mgr *_EntityManagerBehavior) unmarkEntityDirty(entity ec.Entity) {
+	node, ok := mgr.dirtyEntityIndex[entity.GetId()]
+	if !ok || node.V != entity {
+		return
+	}
+	node.Escape()
+	delete(mgr.dirtyEntityIndex, entity.GetId())
+}
+
+func (mgr *_EntityManagerBehavior) isDirtyEntityValid(entity ec.Entity) bool {
+	if entity.GetState() > ec.EntityState_Alive {
+		return false
+	}
+	entityNode, ok := mgr.entityIndex[entity.GetId()]
+	return ok && entityNode.V.Iface == entity
+}
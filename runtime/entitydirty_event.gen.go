@@ -0,0 +1,72 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Code generated by eventc event; DO NOT EDIT.
+
+package runtime
+
+import (
+	event "git.golaxy.org/core/event"
+)
+
+type iAutoEventEntityDirtyFlush interface {
+	EventEntityDirtyFlush() event.IEvent
+}
+
+func BindEventEntityDirtyFlush(auto iAutoEventEntityDirtyFlush, subscriber EventEntityDirtyFlush, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityDirtyFlush](auto.EventEntityDirtyFlush(), subscriber, priority...)
+}
+
+func _EmitEventEntityDirtyFlush(auto iAutoEventEntityDirtyFlush, entityDirtyTracker EntityDirtyTracker) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDirtyFlush()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityDirtyFlush](subscriber).OnEntityDirtyFlush(entityDirtyTracker)
+		return true
+	})
+}
+
+func _EmitEventEntityDirtyFlushWithInterrupt(auto iAutoEventEntityDirtyFlush, interrupt func(entityDirtyTracker EntityDirtyTracker) bool, entityDirtyTracker EntityDirtyTracker) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityDirtyFlush()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityDirtyTracker) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityDirtyFlush](subscriber).OnEntityDirtyFlush(entityDirtyTracker)
+		return true
+	})
+}
+
+func HandleEventEntityDirtyFlush(fun func(entityDirtyTracker EntityDirtyTracker)) EventEntityDirtyFlushHandler {
+	return EventEntityDirtyFlushHandler(fun)
+}
+
+type EventEntityDirtyFlushHandler func(entityDirtyTracker EntityDirtyTracker)
+
+func (h EventEntityDirtyFlushHandler) OnEntityDirtyFlush(entityDirtyTracker EntityDirtyTracker) {
+	h(entityDirtyTracker)
+}
@@ -0,0 +1,29 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+//go:generate go run git.golaxy.org/core/event/eventc event
+//go:generate go run git.golaxy.org/core/event/eventc eventtab --name=entityDirtyEventTab
+package runtime
+
+// EventEntityDirtyFlush 事件：刷新脏实体，在清理脏数据集合前发送，用于收集本帧改变过的组件属性，没有脏实体时也会发送
+// +event-gen:export=0
+// +event-tab-gen:recursion=discard
+type EventEntityDirtyFlush interface {
+	OnEntityDirtyFlush(entityDirtyTracker EntityDirtyTracker)
+}
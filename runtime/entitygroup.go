@@ -0,0 +1,208 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/iface"
+	"git.golaxy.org/core/utils/uid"
+	"slices"
+)
+
+// EntityGroups 实体分组接口，用于将实体组织为具名的集合，实体从实体管理器中删除时，将自动离开其加入的所有分组
+type EntityGroups interface {
+	ictx.CurrentContextProvider
+
+	// AddToGroup 实体加入分组，实体需要在实体管理器中，分组不存在时将自动创建
+	AddToGroup(group string, entityId uid.Id) error
+	// RemoveFromGroup 实体离开分组，分组为空时将自动删除
+	RemoveFromGroup(group string, entityId uid.Id) bool
+	// DeleteGroup 删除分组，所有成员实体将离开分组
+	DeleteGroup(group string)
+	// ContainsInGroup 分组中是否包含实体
+	ContainsInGroup(group string, entityId uid.Id) bool
+	// RangeGroup 按加入顺序遍历分组中的实体
+	RangeGroup(group string, fun generic.Func1[ec.Entity, bool])
+	// GetGroupEntities 按加入顺序获取分组中的所有实体
+	GetGroupEntities(group string) []ec.Entity
+	// CountGroup 获取分组中的实体数量
+	CountGroup(group string) int
+	// GetJoinedGroups 获取实体加入的所有分组
+	GetJoinedGroups(entityId uid.Id) []string
+
+	IEntityGroupEventTab
+}
+
+type _EntityGroup struct {
+	members []uid.Id
+	index   map[uid.Id]struct{}
+}
+
+// AddToGroup 实体加入分组，实体需要在实体管理器中，分组不存在时将自动创建
+func (mgr *_EntityManagerBehavior) AddToGroup(group string, entityId uid.Id) error {
+	if group == "" {
+		return fmt.Errorf("%w: %w: group is empty", ErrEntityGroup, exception.ErrArgs)
+	}
+
+	entity, ok := mgr.GetEntity(entityId)
+	if !ok {
+		return fmt.Errorf("%w: entity %q not exist", ErrEntityGroup, entityId)
+	}
+
+	if entity.GetState() > ec.EntityState_Alive {
+		return fmt.Errorf("%w: invalid entity %q state %q", ErrEntityGroup, entity.GetId(), entity.GetState())
+	}
+
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		entityGroup = &_EntityGroup{
+			index: map[uid.Id]struct{}{},
+		}
+		mgr.groups[group] = entityGroup
+	}
+
+	if _, ok := entityGroup.index[entityId]; ok {
+		return nil
+	}
+
+	entityGroup.members = append(entityGroup.members, entityId)
+	entityGroup.index[entityId] = struct{}{}
+	mgr.memberships[entityId] = append(mgr.memberships[entityId], group)
+
+	_EmitEventEntityGroupJoin(mgr, mgr, group, entity)
+
+	return nil
+}
+
+// RemoveFromGroup 实体离开分组，分组为空时将自动删除
+func (mgr *_EntityManagerBehavior) RemoveFromGroup(group string, entityId uid.Id) bool {
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		return false
+	}
+	return mgr.leaveGroup(group, entityGroup, entityId)
+}
+
+// DeleteGroup 删除分组，所有成员实体将离开分组
+func (mgr *_EntityManagerBehavior) DeleteGroup(group string) {
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		return
+	}
+
+	for _, entityId := range slices.Clone(entityGroup.members) {
+		mgr.leaveGroup(group, entityGroup, entityId)
+	}
+}
+
+// ContainsInGroup 分组中是否包含实体
+func (mgr *_EntityManagerBehavior) ContainsInGroup(group string, entityId uid.Id) bool {
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		return false
+	}
+	_, ok = entityGroup.index[entityId]
+	return ok
+}
+
+// RangeGroup 按加入顺序遍历分组中的实体
+func (mgr *_EntityManagerBehavior) RangeGroup(group string, fun generic.Func1[ec.Entity, bool]) {
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		return
+	}
+
+	for _, entityId := range slices.Clone(entityGroup.members) {
+		entityNode, ok := mgr.entityIndex[entityId]
+		if !ok {
+			continue
+		}
+		if !fun.UnsafeCall(iface.Cache2Iface[ec.Entity](entityNode.V.Cache)) {
+			return
+		}
+	}
+}
+
+// GetGroupEntities 按加入顺序获取分组中的所有实体
+func (mgr *_EntityManagerBehavior) GetGroupEntities(group string) []ec.Entity {
+	var entities []ec.Entity
+	mgr.RangeGroup(group, func(entity ec.Entity) bool {
+		entities = append(entities, entity)
+		return true
+	})
+	return entities
+}
+
+// CountGroup 获取分组中的实体数量
+func (mgr *_EntityManagerBehavior) CountGroup(group string) int {
+	entityGroup, ok := mgr.groups[group]
+	if !ok {
+		return 0
+	}
+	return len(entityGroup.members)
+}
+
+// GetJoinedGroups 获取实体加入的所有分组
+func (mgr *_EntityManagerBehavior) GetJoinedGroups(entityId uid.Id) []string {
+	return slices.Clone(mgr.memberships[entityId])
+}
+
+func (mgr *_EntityManagerBehavior) leaveGroup(group string, entityGroup *_EntityGroup, entityId uid.Id) bool {
+	if _, ok := entityGroup.index[entityId]; !ok {
+		return false
+	}
+
+	delete(entityGroup.index, entityId)
+	if idx := slices.Index(entityGroup.members, entityId); idx >= 0 {
+		entityGroup.members = slices.Delete(entityGroup.members, idx, idx+1)
+	}
+	if len(entityGroup.members) <= 0 {
+		delete(mgr.groups, group)
+	}
+
+	groups := mgr.memberships[entityId]
+	if idx := slices.Index(groups, group); idx >= 0 {
+		groups = slices.Delete(groups, idx, idx+1)
+	}
+	if len(groups) > 0 {
+		mgr.memberships[entityId] = groups
+	} else {
+		delete(mgr.memberships, entityId)
+	}
+
+	entityNode, ok := mgr.entityIndex[entityId]
+	if !ok {
+		return true
+	}
+
+	_EmitEventEntityGroupLeave(mgr, mgr, group, iface.Cache2Iface[ec.Entity](entityNode.V.Cache))
+
+	return true
+}
+
+func (mgr *_EntityManagerBehavior) leaveAllGroups(entity ec.Entity) {
+	for _, group := range slices.Clone(mgr.memberships[entity.GetId()]) {
+		mgr.RemoveFromGroup(group, entity.GetId())
+	}
+}
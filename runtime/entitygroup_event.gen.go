@@ -0,0 +1,119 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Code generated by eventc event; DO NOT EDIT.
+
+package runtime
+
+import (
+	event "git.golaxy.org/core/event"
+	"git.golaxy.org/core/ec"
+)
+
+type iAutoEventEntityGroupJoin interface {
+	EventEntityGroupJoin() event.IEvent
+}
+
+func BindEventEntityGroupJoin(auto iAutoEventEntityGroupJoin, subscriber EventEntityGroupJoin, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityGroupJoin](auto.EventEntityGroupJoin(), subscriber, priority...)
+}
+
+func _EmitEventEntityGroupJoin(auto iAutoEventEntityGroupJoin, entityGroups EntityGroups, group string, entity ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityGroupJoin()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityGroupJoin](subscriber).OnEntityGroupJoin(entityGroups, group, entity)
+		return true
+	})
+}
+
+func _EmitEventEntityGroupJoinWithInterrupt(auto iAutoEventEntityGroupJoin, interrupt func(entityGroups EntityGroups, group string, entity ec.Entity) bool, entityGroups EntityGroups, group string, entity ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityGroupJoin()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityGroups, group, entity) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityGroupJoin](subscriber).OnEntityGroupJoin(entityGroups, group, entity)
+		return true
+	})
+}
+
+func HandleEventEntityGroupJoin(fun func(entityGroups EntityGroups, group string, entity ec.Entity)) EventEntityGroupJoinHandler {
+	return EventEntityGroupJoinHandler(fun)
+}
+
+type EventEntityGroupJoinHandler func(entityGroups EntityGroups, group string, entity ec.Entity)
+
+func (h EventEntityGroupJoinHandler) OnEntityGroupJoin(entityGroups EntityGroups, group string, entity ec.Entity) {
+	h(entityGroups, group, entity)
+}
+
+type iAutoEventEntityGroupLeave interface {
+	EventEntityGroupLeave() event.IEvent
+}
+
+func BindEventEntityGroupLeave(auto iAutoEventEntityGroupLeave, subscriber EventEntityGroupLeave, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityGroupLeave](auto.EventEntityGroupLeave(), subscriber, priority...)
+}
+
+func _EmitEventEntityGroupLeave(auto iAutoEventEntityGroupLeave, entityGroups EntityGroups, group string, entity ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityGroupLeave()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityGroupLeave](subscriber).OnEntityGroupLeave(entityGroups, group, entity)
+		return true
+	})
+}
+
+func _EmitEventEntityGroupLeaveWithInterrupt(auto iAutoEventEntityGroupLeave, interrupt func(entityGroups EntityGroups, group string, entity ec.Entity) bool, entityGroups EntityGroups, group string, entity ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityGroupLeave()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityGroups, group, entity) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityGroupLeave](subscriber).OnEntityGroupLeave(entityGroups, group, entity)
+		return true
+	})
+}
+
+func HandleEventEntityGroupLeave(fun func(entityGroups EntityGroups, group string, entity ec.Entity)) EventEntityGroupLeaveHandler {
+	return EventEntityGroupLeaveHandler(fun)
+}
+
+type EventEntityGroupLeaveHandler func(entityGroups EntityGroups, group string, entity ec.Entity)
+
+func (h EventEntityGroupLeaveHandler) OnEntityGroupLeave(entityGroups EntityGroups, group string, entity ec.Entity) {
+	h(entityGroups, group, entity)
+}
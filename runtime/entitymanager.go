@@ -29,6 +29,7 @@ import (
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/uid"
+	"math"
 )
 
 // EntityManager 实体管理器接口
@@ -37,10 +38,16 @@ type EntityManager interface {
 
 	// AddEntity 添加实体
 	AddEntity(entity ec.Entity) error
+	// AddEntities 批量添加实体，返回与实体一一对应的错误
+	AddEntities(entities []ec.Entity) []error
 	// RemoveEntity 删除实体
 	RemoveEntity(id uid.Id)
 	// GetEntity 查询实体
 	GetEntity(id uid.Id) (ec.Entity, bool)
+	// GetEntityByHandle 使用实体句柄查询实体，只有实体为同一代且未销毁时才能查询到
+	GetEntityByHandle(handle ec.EntityHandle) (ec.Entity, bool)
+	// GetComponentByHandle 使用组件句柄查询组件，只有实体与组件均为同一代且未销毁时才能查询到
+	GetComponentByHandle(handle ec.ComponentHandle) (ec.Component, bool)
 	// ContainsEntity 实体是否存在
 	ContainsEntity(id uid.Id) bool
 	// RangeEntities 遍历所有实体
@@ -67,13 +74,21 @@ type (
 )
 
 type _EntityManagerBehavior struct {
-	ctx         Context
-	entityIndex map[uid.Id]_EntityNode
-	entityList  generic.List[iface.FaceAny]
-	treeNodes   map[uid.Id]*_TreeNode
+	ctx              Context
+	entityIndex      map[uid.Id]_EntityNode
+	entityList       generic.List[iface.FaceAny]
+	treeNodes        map[uid.Id]*_TreeNode
+	relations        map[string]*_EntityRelation
+	groups           map[string]*_EntityGroup
+	memberships      map[uid.Id][]string
+	dirtyEntities    generic.List[ec.Entity]
+	dirtyEntityIndex map[uid.Id]*generic.Node[ec.Entity]
 
 	entityManagerEventTab
 	entityTreeEventTab
+	entityRelationEventTab
+	entityGroupEventTab
+	entityDirtyEventTab
 }
 
 func (mgr *_EntityManagerBehavior) init(ctx Context) {
@@ -84,9 +99,15 @@ func (mgr *_EntityManagerBehavior) init(ctx Context) {
 	mgr.ctx = ctx
 	mgr.entityIndex = map[uid.Id]_EntityNode{}
 	mgr.treeNodes = map[uid.Id]*_TreeNode{}
+	mgr.relations = map[string]*_EntityRelation{}
+	mgr.groups = map[string]*_EntityGroup{}
+	mgr.memberships = map[uid.Id][]string{}
 
 	ctx.ActivateEvent(&mgr.entityManagerEventTab, event.EventRecursion_Allow)
 	ctx.ActivateEvent(&mgr.entityTreeEventTab, event.EventRecursion_Allow)
+	ctx.ActivateEvent(&mgr.entityRelationEventTab, event.EventRecursion_Allow)
+	ctx.ActivateEvent(&mgr.entityGroupEventTab, event.EventRecursion_Allow)
+	ctx.ActivateEvent(&mgr.entityDirtyEventTab, event.EventRecursion_Allow)
 }
 
 func (mgr *_EntityManagerBehavior) changeRunningStatus(status RunningStatus, args ...any) {
@@ -96,8 +117,14 @@ func (mgr *_EntityManagerBehavior) changeRunningStatus(status RunningStatus, arg
 			_EmitEventEntityManagerAddEntityWithInterrupt(mgr, func(entityManager EntityManager, entity ec.Entity) bool {
 				return entity.GetState() > ec.EntityState_Alive
 			}, mgr, entity)
+
+			if err := ec.UnsafeEntity(entity).GetLifecycleError(); err != nil {
+				ictx.ReportError(mgr.ctx, fmt.Errorf("%w: entity %q activate failed, %w", ErrEntityManager, entity.GetId(), err))
+			}
 			return true
 		})
+	case RunningStatus_FrameLoopEnd:
+		mgr.FlushDirty()
 	case RunningStatus_Terminating:
 		mgr.ReversedRangeEntities(func(entity ec.Entity) bool {
 			entity.DestroySelf()
@@ -106,6 +133,11 @@ func (mgr *_EntityManagerBehavior) changeRunningStatus(status RunningStatus, arg
 	case RunningStatus_Terminated:
 		mgr.entityManagerEventTab.Close()
 		mgr.entityTreeEventTab.Close()
+		mgr.entityRelationEventTab.Close()
+		mgr.entityGroupEventTab.Close()
+		mgr.entityDirtyEventTab.Close()
+		mgr.dirtyEntities = generic.List[ec.Entity]{}
+		mgr.dirtyEntityIndex = nil
 	}
 }
 
@@ -121,7 +153,12 @@ func (mgr *_EntityManagerBehavior) GetConcurrentContext() iface.Cache {
 
 // AddEntity 添加实体
 func (mgr *_EntityManagerBehavior) AddEntity(entity ec.Entity) error {
-	return mgr.addEntity(entity, uid.Nil)
+	return mgr.addEntity(entity, uid.Nil, -1)
+}
+
+// AddEntities 批量添加实体，返回与实体一一对应的错误
+func (mgr *_EntityManagerBehavior) AddEntities(entities []ec.Entity) []error {
+	return mgr.addEntities(entities)
 }
 
 // RemoveEntity 删除实体
@@ -143,6 +180,16 @@ func (mgr *_EntityManagerBehavior) GetEntity(id uid.Id) (ec.Entity, bool) {
 	return iface.Cache2Iface[ec.Entity](entityNode.V.Cache), true
 }
 
+// GetEntityByHandle 使用实体句柄查询实体，只有实体为同一代且未销毁时才能查询到
+func (mgr *_EntityManagerBehavior) GetEntityByHandle(handle ec.EntityHandle) (ec.Entity, bool) {
+	return handle.Resolve(mgr)
+}
+
+// GetComponentByHandle 使用组件句柄查询组件，只有实体与组件均为同一代且未销毁时才能查询到
+func (mgr *_EntityManagerBehavior) GetComponentByHandle(handle ec.ComponentHandle) (ec.Component, bool) {
+	return handle.Resolve(mgr)
+}
+
 // ContainsEntity 实体是否存在
 func (mgr *_EntityManagerBehavior) ContainsEntity(id uid.Id) bool {
 	_, ok := mgr.entityIndex[id]
@@ -205,6 +252,12 @@ func (mgr *_EntityManagerBehavior) OnComponentManagerAddComponents(entity ec.Ent
 	_EmitEventEntityManagerEntityAddComponents(mgr, mgr, entity, components)
 }
 
+func (mgr *_EntityManagerBehavior) OnComponentManagerReplaceComponent(entity ec.Entity, oldComponent, newComponent ec.Component) {
+	mgr.initComponent(entity, newComponent)
+
+	_EmitEventEntityManagerEntityReplaceComponent(mgr, mgr, entity, oldComponent, newComponent)
+}
+
 func (mgr *_EntityManagerBehavior) OnComponentManagerRemoveComponent(entity ec.Entity, component ec.Component) {
 	_EmitEventEntityManagerEntityRemoveComponent(mgr, mgr, entity, component)
 }
@@ -213,52 +266,158 @@ func (mgr *_EntityManagerBehavior) OnComponentManagerFirstTouchComponent(entity
 	_EmitEventEntityManagerEntityFirstTouchComponent(mgr, mgr, entity, component)
 }
 
-func (mgr *_EntityManagerBehavior) addEntity(entity ec.Entity, parentId uid.Id) error {
+func (mgr *_EntityManagerBehavior) OnEntityActiveChanged(entity ec.Entity, active bool) {
+	if entity.GetState() > ec.EntityState_Alive {
+		return
+	}
+
+	mgr.RangeChildren(entity.GetId(), func(child ec.Entity) bool {
+		ec.UnsafeEntity(child).UpdateActiveInHierarchy()
+		return entity.GetActiveInHierarchy() == active
+	})
+}
+
+func (mgr *_EntityManagerBehavior) addEntity(entity ec.Entity, parentId uid.Id, idx int) error {
+	parent, err := mgr.prepareEntity(entity, parentId)
+	if err != nil {
+		return err
+	}
+
+	if entity.GetScope() == ec.Scope_Global {
+		_, loaded, err := service.Current(mgr).GetEntityManager().GetOrAddEntity(entity)
+		if err != nil {
+			return fmt.Errorf("%w: entity %q add to service entity-manager failed, %w", ErrEntityManager, entity.GetId(), err)
+		}
+		if loaded {
+			return fmt.Errorf("%w: entity %q already exists in service entity-manager", ErrEntityManager, entity.GetId())
+		}
+	}
+
+	if err := mgr.commitEntity(entity, parent, idx); err != nil {
+		return err
+	}
+
+	_EmitEventEntityManagerAddEntities(mgr, mgr, []ec.Entity{entity})
+
+	return nil
+}
+
+func (mgr *_EntityManagerBehavior) addEntities(entities []ec.Entity) []error {
+	errs := make([]error, len(entities))
+
+	pending := make(map[uid.Id]struct{}, len(entities))
+
+	var globals []ec.ConcurrentEntity
+	var globalsAt []int
+
+	for i, entity := range entities {
+		if _, err := mgr.prepareEntity(entity, uid.Nil); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if _, ok := pending[entity.GetId()]; ok {
+			errs[i] = fmt.Errorf("%w: entity %q already exists in entity-manager", ErrEntityManager, entity.GetId())
+			continue
+		}
+		pending[entity.GetId()] = struct{}{}
+
+		if entity.GetScope() == ec.Scope_Global {
+			globals = append(globals, entity)
+			globalsAt = append(globalsAt, i)
+		}
+	}
+
+	if len(globals) > 0 {
+		for i, err := range service.Current(mgr).GetEntityManager().GetOrAddEntities(globals) {
+			if err != nil {
+				errs[globalsAt[i]] = fmt.Errorf("%w: entity %q add to service entity-manager failed, %w", ErrEntityManager, globals[i].GetId(), err)
+			}
+		}
+	}
+
+	added := make([]ec.Entity, 0, len(entities))
+
+	mgr.reserveEntities(len(pending))
+
+	for i, entity := range entities {
+		if errs[i] != nil {
+			continue
+		}
+
+		if err := mgr.commitEntity(entity, nil, -1); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		added = append(added, entity)
+	}
+
+	if len(added) > 0 {
+		_EmitEventEntityManagerAddEntities(mgr, mgr, added)
+	}
+
+	return errs
+}
+
+// reserveEntities 批量添加的实体数量超过已有实体数量时，按所需容量重建实体索引，每次重建至少使容量翻倍，均摊后复制开销为线性
+func (mgr *_EntityManagerBehavior) reserveEntities(n int) {
+	if n <= len(mgr.entityIndex) {
+		return
+	}
+
+	entityIndex := make(map[uid.Id]_EntityNode, len(mgr.entityIndex)+n)
+	for id, entityNode := range mgr.entityIndex {
+		entityIndex[id] = entityNode
+	}
+	mgr.entityIndex = entityIndex
+}
+
+func (mgr *_EntityManagerBehavior) prepareEntity(entity ec.Entity, parentId uid.Id) (ec.Entity, error) {
 	if entity == nil {
 		exception.Panicf("%w: %w: entity is nil", ErrEntityManager, exception.ErrArgs)
 	}
 
 	if entity.GetState() != ec.EntityState_Birth {
-		return fmt.Errorf("%w: invalid entity %q state %q", ErrEntityManager, entity.GetId(), entity.GetState())
+		return nil, fmt.Errorf("%w: invalid entity %q state %q", ErrEntityManager, entity.GetId(), entity.GetState())
 	}
 
 	switch entity.GetScope() {
 	case ec.Scope_Local, ec.Scope_Global:
 		break
 	default:
-		return fmt.Errorf("%w: invalid entity %q scope %q", ErrEntityManager, entity.GetId(), entity.GetScope())
+		return nil, fmt.Errorf("%w: invalid entity %q scope %q", ErrEntityManager, entity.GetId(), entity.GetScope())
 	}
 
 	mgr.initEntity(entity)
 
 	parent, err := mgr.fetchParent(entity, parentId)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if _, ok := mgr.entityIndex[entity.GetId()]; ok {
-		return fmt.Errorf("%w: entity %q already exists in entity-manager", ErrEntityManager, entity.GetId())
+		return nil, fmt.Errorf("%w: entity %q already exists in entity-manager", ErrEntityManager, entity.GetId())
 	}
 
 	if parent != nil {
 		if _, ok := mgr.treeNodes[entity.GetId()]; ok {
-			return fmt.Errorf("%w: entity %q already exists in entity-tree", ErrEntityManager, entity.GetId())
+			return nil, fmt.Errorf("%w: entity %q already exists in entity-tree", ErrEntityManager, entity.GetId())
 		}
 	}
 
-	if entity.GetScope() == ec.Scope_Global {
-		_, loaded, err := service.Current(mgr).GetEntityManager().GetOrAddEntity(entity)
-		if err != nil {
-			return fmt.Errorf("%w: entity %q add to service entity-manager failed, %w", ErrEntityManager, entity.GetId(), err)
-		}
-		if loaded {
-			return fmt.Errorf("%w: entity %q already exists in service entity-manager", ErrEntityManager, entity.GetId())
-		}
+	if err := mgr.injectAddIns(entity); err != nil {
+		return nil, err
 	}
 
+	return parent, nil
+}
+
+func (mgr *_EntityManagerBehavior) commitEntity(entity ec.Entity, parent ec.Entity, idx int) error {
 	mgr.entityIndex[entity.GetId()] = mgr.entityList.PushBack(iface.MakeFaceAny(entity))
 
 	mgr.observeEntity(entity)
+	mgr.markEntityDirty(entity)
 
 	ec.UnsafeEntity(entity).SetState(ec.EntityState_Enter)
 
@@ -266,7 +425,7 @@ func (mgr *_EntityManagerBehavior) addEntity(entity ec.Entity, parentId uid.Id)
 	ec.UnsafeEntity(entity).SetTreeNodeParent(nil)
 
 	if parent != nil {
-		if err := mgr.appendToParentNode(entity, parent); err != nil {
+		if err := mgr.appendToParentNode(entity, parent, idx); err != nil {
 			exception.Panicf("%w: entity %q append to parent %q failed, %w", ErrEntityManager, entity.GetId(), parent.GetId(), err)
 		}
 	}
@@ -275,6 +434,10 @@ func (mgr *_EntityManagerBehavior) addEntity(entity ec.Entity, parentId uid.Id)
 		return entity.GetState() > ec.EntityState_Alive
 	}, mgr, entity)
 
+	if err := ec.UnsafeEntity(entity).GetLifecycleError(); err != nil {
+		return fmt.Errorf("%w: entity %q activate failed, %w", ErrEntityManager, entity.GetId(), err)
+	}
+
 	if parent != nil {
 		if err := mgr.attachToParentNode(entity, parent); err != nil {
 			return fmt.Errorf("%w: entity %q attach to parent %q failed, %w", ErrEntityManager, entity.GetId(), parent.GetId(), err)
@@ -309,6 +472,10 @@ func (mgr *_EntityManagerBehavior) removeEntity(id uid.Id) {
 
 	mgr.detachFromParentNode(entity)
 
+	mgr.unlinkAllRelations(entity)
+	mgr.leaveAllGroups(entity)
+	mgr.unmarkEntityDirty(entity)
+
 	_EmitEventEntityManagerRemoveEntity(mgr, mgr, entity)
 
 	mgr.removeFromParentNode(entity)
@@ -369,6 +536,14 @@ func (mgr *_EntityManagerBehavior) initComponent(entity ec.Entity, comp ec.Compo
 func (mgr *_EntityManagerBehavior) observeEntity(entity ec.Entity) {
 	ec.BindEventComponentManagerAddComponents(entity, mgr)
 	ec.BindEventComponentManagerRemoveComponent(entity, mgr)
+	ec.BindEventComponentManagerReplaceComponent(entity, mgr)
+	ec.BindEventEntityActiveChanged(entity, mgr, math.MaxInt32) // 父实体处理完毕后，再处理子实体
+	ec.BindEventEntityDirty(entity, mgr)
+
+	// 组件添加或替换至实体前注入插件，插件缺失时不会添加或替换组件
+	ec.UnsafeEntity(entity).SetComponentInjector(func(comp ec.Component) error {
+		return injectAddIns(comp, mgr.addInProviders())
+	})
 
 	if ec.UnsafeEntity(entity).GetOptions().ComponentAwakeOnFirstTouch {
 		ec.BindEventComponentManagerFirstTouchComponent(entity, mgr)
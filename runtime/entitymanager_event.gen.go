@@ -210,6 +210,52 @@ func (h EventEntityManagerEntityRemoveComponentHandler) OnEntityManagerEntityRem
 	h(entityManager, entity, component)
 }
 
+type iAutoEventEntityManagerEntityReplaceComponent interface {
+	EventEntityManagerEntityReplaceComponent() event.IEvent
+}
+
+func BindEventEntityManagerEntityReplaceComponent(auto iAutoEventEntityManagerEntityReplaceComponent, subscriber EventEntityManagerEntityReplaceComponent, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityManagerEntityReplaceComponent](auto.EventEntityManagerEntityReplaceComponent(), subscriber, priority...)
+}
+
+func _EmitEventEntityManagerEntityReplaceComponent(auto iAutoEventEntityManagerEntityReplaceComponent, entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerEntityReplaceComponent()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityManagerEntityReplaceComponent](subscriber).OnEntityManagerEntityReplaceComponent(entityManager, entity, oldComponent, newComponent)
+		return true
+	})
+}
+
+func _EmitEventEntityManagerEntityReplaceComponentWithInterrupt(auto iAutoEventEntityManagerEntityReplaceComponent, interrupt func(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) bool, entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerEntityReplaceComponent()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityManager, entity, oldComponent, newComponent) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityManagerEntityReplaceComponent](subscriber).OnEntityManagerEntityReplaceComponent(entityManager, entity, oldComponent, newComponent)
+		return true
+	})
+}
+
+func HandleEventEntityManagerEntityReplaceComponent(fun func(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component)) EventEntityManagerEntityReplaceComponentHandler {
+	return EventEntityManagerEntityReplaceComponentHandler(fun)
+}
+
+type EventEntityManagerEntityReplaceComponentHandler func(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component)
+
+func (h EventEntityManagerEntityReplaceComponentHandler) OnEntityManagerEntityReplaceComponent(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component) {
+	h(entityManager, entity, oldComponent, newComponent)
+}
+
 type iAutoEventEntityManagerEntityFirstTouchComponent interface {
 	EventEntityManagerEntityFirstTouchComponent() event.IEvent
 }
@@ -255,3 +301,49 @@ type EventEntityManagerEntityFirstTouchComponentHandler func(entityManager Entit
 func (h EventEntityManagerEntityFirstTouchComponentHandler) OnEntityManagerEntityFirstTouchComponent(entityManager EntityManager, entity ec.Entity, component ec.Component) {
 	h(entityManager, entity, component)
 }
+
+type iAutoEventEntityManagerAddEntities interface {
+	EventEntityManagerAddEntities() event.IEvent
+}
+
+func BindEventEntityManagerAddEntities(auto iAutoEventEntityManagerAddEntities, subscriber EventEntityManagerAddEntities, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityManagerAddEntities](auto.EventEntityManagerAddEntities(), subscriber, priority...)
+}
+
+func _EmitEventEntityManagerAddEntities(auto iAutoEventEntityManagerAddEntities, entityManager EntityManager, entities []ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerAddEntities()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityManagerAddEntities](subscriber).OnEntityManagerAddEntities(entityManager, entities)
+		return true
+	})
+}
+
+func _EmitEventEntityManagerAddEntitiesWithInterrupt(auto iAutoEventEntityManagerAddEntities, interrupt func(entityManager EntityManager, entities []ec.Entity) bool, entityManager EntityManager, entities []ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerAddEntities()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityManager, entities) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityManagerAddEntities](subscriber).OnEntityManagerAddEntities(entityManager, entities)
+		return true
+	})
+}
+
+func HandleEventEntityManagerAddEntities(fun func(entityManager EntityManager, entities []ec.Entity)) EventEntityManagerAddEntitiesHandler {
+	return EventEntityManagerAddEntitiesHandler(fun)
+}
+
+type EventEntityManagerAddEntitiesHandler func(entityManager EntityManager, entities []ec.Entity)
+
+func (h EventEntityManagerAddEntitiesHandler) OnEntityManagerAddEntities(entityManager EntityManager, entities []ec.Entity) {
+	h(entityManager, entities)
+}
@@ -47,8 +47,20 @@ type EventEntityManagerEntityRemoveComponent interface {
 	OnEntityManagerEntityRemoveComponent(entityManager EntityManager, entity ec.Entity, component ec.Component)
 }
 
+// EventEntityManagerEntityReplaceComponent 事件：实体管理器中的实体替换组件
+// +event-gen:export=0
+type EventEntityManagerEntityReplaceComponent interface {
+	OnEntityManagerEntityReplaceComponent(entityManager EntityManager, entity ec.Entity, oldComponent, newComponent ec.Component)
+}
+
 // EventEntityManagerEntityFirstTouchComponent 事件：实体管理器中的实体首次访问组件
 // +event-gen:export=0
 type EventEntityManagerEntityFirstTouchComponent interface {
 	OnEntityManagerEntityFirstTouchComponent(entityManager EntityManager, entity ec.Entity, component ec.Component)
 }
+
+// EventEntityManagerAddEntities 事件：实体管理器添加实体后的聚合通知，单个添加与批量添加时均只通知一次，需要批量处理的观察者可以选择监听此事件
+// +event-gen:export=0
+type EventEntityManagerAddEntities interface {
+	OnEntityManagerAddEntities(entityManager EntityManager, entities []ec.Entity)
+}
@@ -30,7 +30,9 @@ type IEntityManagerEventTab interface {
 	EventEntityManagerRemoveEntity() event.IEvent
 	EventEntityManagerEntityAddComponents() event.IEvent
 	EventEntityManagerEntityRemoveComponent() event.IEvent
+	EventEntityManagerEntityReplaceComponent() event.IEvent
 	EventEntityManagerEntityFirstTouchComponent() event.IEvent
+	EventEntityManagerAddEntities() event.IEvent
 }
 
 var (
@@ -39,10 +41,12 @@ var (
 	EventEntityManagerRemoveEntityId = _entityManagerEventTabId + 1
 	EventEntityManagerEntityAddComponentsId = _entityManagerEventTabId + 2
 	EventEntityManagerEntityRemoveComponentId = _entityManagerEventTabId + 3
-	EventEntityManagerEntityFirstTouchComponentId = _entityManagerEventTabId + 4
+	EventEntityManagerEntityReplaceComponentId = _entityManagerEventTabId + 4
+	EventEntityManagerEntityFirstTouchComponentId = _entityManagerEventTabId + 5
+	EventEntityManagerAddEntitiesId = _entityManagerEventTabId + 6
 )
 
-type entityManagerEventTab [5]event.Event
+type entityManagerEventTab [7]event.Event
 
 func (eventTab *entityManagerEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
 	(*eventTab)[0].Init(autoRecover, reportError, recursion)
@@ -50,6 +54,8 @@ func (eventTab *entityManagerEventTab) Init(autoRecover bool, reportError chan e
 	(*eventTab)[2].Init(autoRecover, reportError, recursion)
 	(*eventTab)[3].Init(autoRecover, reportError, recursion)
 	(*eventTab)[4].Init(autoRecover, reportError, recursion)
+	(*eventTab)[5].Init(autoRecover, reportError, recursion)
+	(*eventTab)[6].Init(autoRecover, reportError, recursion)
 }
 
 func (eventTab *entityManagerEventTab) Open() {
@@ -101,6 +107,14 @@ func (eventTab *entityManagerEventTab) EventEntityManagerEntityRemoveComponent()
 	return &(*eventTab)[3]
 }
 
-func (eventTab *entityManagerEventTab) EventEntityManagerEntityFirstTouchComponent() event.IEvent {
+func (eventTab *entityManagerEventTab) EventEntityManagerEntityReplaceComponent() event.IEvent {
 	return &(*eventTab)[4]
 }
+
+func (eventTab *entityManagerEventTab) EventEntityManagerEntityFirstTouchComponent() event.IEvent {
+	return &(*eventTab)[5]
+}
+
+func (eventTab *entityManagerEventTab) EventEntityManagerAddEntities() event.IEvent {
+	return &(*eventTab)[6]
+}
@@ -0,0 +1,124 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/extension"
+	"git.golaxy.org/core/service"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// injectAddIns 向实体与组件中实现了extension.AddInInjector接口的字段注入插件
+func (mgr *_EntityManagerBehavior) injectAddIns(entity ec.Entity) (err error) {
+	if err := injectAddIns(entity, mgr.addInProviders()); err != nil {
+		return fmt.Errorf("%w: entity %q inject addIn failed, %w", ErrEntityManager, entity.GetId(), err)
+	}
+
+	ec.UnsafeEntity(entity).RangeAllComponents(func(comp ec.Component) bool {
+		err = mgr.injectComponentAddIns(entity, comp)
+		return err == nil
+	})
+
+	return err
+}
+
+func (mgr *_EntityManagerBehavior) injectComponentAddIns(entity ec.Entity, comp ec.Component) error {
+	if err := injectAddIns(comp, mgr.addInProviders()); err != nil {
+		return fmt.Errorf("%w: entity %q component %q inject addIn failed, %w", ErrEntityManager, entity.GetId(), comp.GetName(), err)
+	}
+	return nil
+}
+
+func (mgr *_EntityManagerBehavior) addInProviders() []extension.AddInProvider {
+	return []extension.AddInProvider{mgr.ctx, service.Current(mgr)}
+}
+
+type _AddInInjection struct {
+	index    []int
+	name     string
+	optional bool
+}
+
+var (
+	addInInjectorRT   = reflect.TypeFor[extension.AddInInjector]()
+	addInInjectionsRT sync.Map
+)
+
+func injectAddIns(target any, providers []extension.AddInProvider) error {
+	targetRV := reflect.ValueOf(target)
+	for targetRV.Kind() == reflect.Pointer {
+		if targetRV.IsNil() {
+			return nil
+		}
+		targetRV = targetRV.Elem()
+	}
+
+	if targetRV.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, injection := range parseAddInInjections(targetRV.Type()) {
+		fieldRV, err := targetRV.FieldByIndexErr(injection.index)
+		if err != nil {
+			continue
+		}
+
+		if err := fieldRV.Addr().Interface().(extension.AddInInjector).InjectAddIn(injection.name, providers...); err != nil && !injection.optional {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseAddInInjections(targetRT reflect.Type) []_AddInInjection {
+	if v, ok := addInInjectionsRT.Load(targetRT); ok {
+		return v.([]_AddInInjection)
+	}
+
+	var injections []_AddInInjection
+
+	for _, field := range reflect.VisibleFields(targetRT) {
+		if !field.IsExported() || !reflect.PointerTo(field.Type).Implements(addInInjectorRT) {
+			continue
+		}
+
+		injection := _AddInInjection{index: field.Index}
+
+		for _, opt := range strings.Split(field.Tag.Get("golaxy"), ",") {
+			opt = strings.TrimSpace(opt)
+
+			if v, ok := strings.CutPrefix(opt, "addin="); ok {
+				injection.name = v
+			} else if opt == "optional" {
+				injection.optional = true
+			}
+		}
+
+		injections = append(injections, injection)
+	}
+
+	v, _ := addInInjectionsRT.LoadOrStore(targetRT, injections)
+	return v.([]_AddInInjection)
+}
@@ -0,0 +1,356 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/iface"
+	"git.golaxy.org/core/utils/uid"
+	"maps"
+	"slices"
+)
+
+// RelationCardinality 实体关系基数
+type RelationCardinality int32
+
+const (
+	RelationCardinality_ManyToMany RelationCardinality = iota // 多对多
+	RelationCardinality_ManyToOne                             // 多对一，源实体最多关联一个目标实体，重复关联时替换旧关联，例如：owner、target、follows
+	RelationCardinality_OneToMany                             // 一对多，目标实体最多被一个源实体关联，重复关联时替换旧关联
+	RelationCardinality_OneToOne                              // 一对一，源实体与目标实体均最多关联一个实体，重复关联时替换旧关联
+)
+
+// EntityRelations 实体关系接口，用于在实体树之外建立具名的实体关联，实体从实体管理器中删除时，将自动取消其所有关联
+type EntityRelations interface {
+	ictx.CurrentContextProvider
+
+	// DeclareRelation 声明关系类型，未声明的关系类型首次关联时将声明为多对多
+	DeclareRelation(kind string, cardinality RelationCardinality) error
+	// GetRelationCardinality 获取关系类型的基数
+	GetRelationCardinality(kind string) (RelationCardinality, bool)
+	// Link 关联实体，源实体与目标实体需要在实体管理器中
+	Link(kind string, sourceId, targetId uid.Id) error
+	// Unlink 取消关联实体
+	Unlink(kind string, sourceId, targetId uid.Id) bool
+	// UnlinkAll 取消实体在关系类型中作为源实体或目标实体的所有关联
+	UnlinkAll(kind string, entityId uid.Id)
+	// IsLinked 实体是否已关联
+	IsLinked(kind string, sourceId, targetId uid.Id) bool
+	// RangeTargets 遍历源实体关联的目标实体
+	RangeTargets(kind string, sourceId uid.Id, fun generic.Func1[ec.Entity, bool])
+	// GetTargets 获取源实体关联的所有目标实体
+	GetTargets(kind string, sourceId uid.Id) []ec.Entity
+	// GetTarget 获取源实体关联的第一个目标实体，适用于多对一、一对一关系
+	GetTarget(kind string, sourceId uid.Id) (ec.Entity, bool)
+	// CountTargets 获取源实体关联的目标实体数量
+	CountTargets(kind string, sourceId uid.Id) int
+	// RangeSources 反向遍历关联目标实体的源实体
+	RangeSources(kind string, targetId uid.Id, fun generic.Func1[ec.Entity, bool])
+	// GetSources 反向获取关联目标实体的所有源实体
+	GetSources(kind string, targetId uid.Id) []ec.Entity
+	// GetSource 反向获取关联目标实体的第一个源实体，适用于一对多、一对一关系
+	GetSource(kind string, targetId uid.Id) (ec.Entity, bool)
+	// CountSources 反向获取关联目标实体的源实体数量
+	CountSources(kind string, targetId uid.Id) int
+
+	IEntityRelationEventTab
+}
+
+type _EntityRelation struct {
+	cardinality RelationCardinality
+	targets     map[uid.Id][]uid.Id
+	sources     map[uid.Id][]uid.Id
+}
+
+// DeclareRelation 声明关系类型，未声明的关系类型首次关联时将声明为多对多
+func (mgr *_EntityManagerBehavior) DeclareRelation(kind string, cardinality RelationCardinality) error {
+	if kind == "" {
+		return fmt.Errorf("%w: %w: kind is empty", ErrEntityRelation, exception.ErrArgs)
+	}
+
+	switch cardinality {
+	case RelationCardinality_ManyToMany, RelationCardinality_ManyToOne, RelationCardinality_OneToMany, RelationCardinality_OneToOne:
+		break
+	default:
+		return fmt.Errorf("%w: %w: invalid cardinality %d", ErrEntityRelation, exception.ErrArgs, cardinality)
+	}
+
+	if relation, ok := mgr.relations[kind]; ok {
+		if relation.cardinality != cardinality {
+			return fmt.Errorf("%w: relation %q already declared with cardinality %d", ErrEntityRelation, kind, relation.cardinality)
+		}
+		return nil
+	}
+
+	mgr.relations[kind] = &_EntityRelation{
+		cardinality: cardinality,
+		targets:     map[uid.Id][]uid.Id{},
+		sources:     map[uid.Id][]uid.Id{},
+	}
+
+	return nil
+}
+
+// GetRelationCardinality 获取关系类型的基数
+func (mgr *_EntityManagerBehavior) GetRelationCardinality(kind string) (RelationCardinality, bool) {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return 0, false
+	}
+	return relation.cardinality, true
+}
+
+// Link 关联实体，源实体与目标实体需要在实体管理器中
+func (mgr *_EntityManagerBehavior) Link(kind string, sourceId, targetId uid.Id) error {
+	if kind == "" {
+		return fmt.Errorf("%w: %w: kind is empty", ErrEntityRelation, exception.ErrArgs)
+	}
+
+	if sourceId == targetId {
+		return fmt.Errorf("%w: %w: entity %q can't link to itself", ErrEntityRelation, exception.ErrArgs, sourceId)
+	}
+
+	source, err := mgr.fetchRelationEntity(sourceId)
+	if err != nil {
+		return err
+	}
+
+	target, err := mgr.fetchRelationEntity(targetId)
+	if err != nil {
+		return err
+	}
+
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		if err := mgr.DeclareRelation(kind, RelationCardinality_ManyToMany); err != nil {
+			return err
+		}
+		relation = mgr.relations[kind]
+	}
+
+	if slices.Contains(relation.targets[sourceId], targetId) {
+		return nil
+	}
+
+	switch relation.cardinality {
+	case RelationCardinality_ManyToOne, RelationCardinality_OneToOne:
+		for _, oldTargetId := range slices.Clone(relation.targets[sourceId]) {
+			mgr.unlink(kind, relation, sourceId, oldTargetId)
+		}
+	}
+
+	switch relation.cardinality {
+	case RelationCardinality_OneToMany, RelationCardinality_OneToOne:
+		for _, oldSourceId := range slices.Clone(relation.sources[targetId]) {
+			mgr.unlink(kind, relation, oldSourceId, targetId)
+		}
+	}
+
+	relation.targets[sourceId] = append(relation.targets[sourceId], targetId)
+	relation.sources[targetId] = append(relation.sources[targetId], sourceId)
+
+	_EmitEventEntityRelationLink(mgr, mgr, kind, source, target)
+
+	return nil
+}
+
+// Unlink 取消关联实体
+func (mgr *_EntityManagerBehavior) Unlink(kind string, sourceId, targetId uid.Id) bool {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return false
+	}
+	return mgr.unlink(kind, relation, sourceId, targetId)
+}
+
+// UnlinkAll 取消实体在关系类型中作为源实体或目标实体的所有关联
+func (mgr *_EntityManagerBehavior) UnlinkAll(kind string, entityId uid.Id) {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return
+	}
+
+	for _, targetId := range slices.Clone(relation.targets[entityId]) {
+		mgr.unlink(kind, relation, entityId, targetId)
+	}
+
+	for _, sourceId := range slices.Clone(relation.sources[entityId]) {
+		mgr.unlink(kind, relation, sourceId, entityId)
+	}
+}
+
+// IsLinked 实体是否已关联
+func (mgr *_EntityManagerBehavior) IsLinked(kind string, sourceId, targetId uid.Id) bool {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return false
+	}
+	return slices.Contains(relation.targets[sourceId], targetId)
+}
+
+// RangeTargets 遍历源实体关联的目标实体
+func (mgr *_EntityManagerBehavior) RangeTargets(kind string, sourceId uid.Id, fun generic.Func1[ec.Entity, bool]) {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return
+	}
+	mgr.rangeRelationEntities(slices.Clone(relation.targets[sourceId]), fun)
+}
+
+// GetTargets 获取源实体关联的所有目标实体
+func (mgr *_EntityManagerBehavior) GetTargets(kind string, sourceId uid.Id) []ec.Entity {
+	var entities []ec.Entity
+	mgr.RangeTargets(kind, sourceId, func(entity ec.Entity) bool {
+		entities = append(entities, entity)
+		return true
+	})
+	return entities
+}
+
+// GetTarget 获取源实体关联的第一个目标实体，适用于多对一、一对一关系
+func (mgr *_EntityManagerBehavior) GetTarget(kind string, sourceId uid.Id) (ec.Entity, bool) {
+	var target ec.Entity
+	mgr.RangeTargets(kind, sourceId, func(entity ec.Entity) bool {
+		target = entity
+		return false
+	})
+	return target, target != nil
+}
+
+// CountTargets 获取源实体关联的目标实体数量
+func (mgr *_EntityManagerBehavior) CountTargets(kind string, sourceId uid.Id) int {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return 0
+	}
+	return len(relation.targets[sourceId])
+}
+
+// RangeSources 反向遍历关联目标实体的源实体
+func (mgr *_EntityManagerBehavior) RangeSources(kind string, targetId uid.Id, fun generic.Func1[ec.Entity, bool]) {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return
+	}
+	mgr.rangeRelationEntities(slices.Clone(relation.sources[targetId]), fun)
+}
+
+// GetSources 反向获取关联目标实体的所有源实体
+func (mgr *_EntityManagerBehavior) GetSources(kind string, targetId uid.Id) []ec.Entity {
+	var entities []ec.Entity
+	mgr.RangeSources(kind, targetId, func(entity ec.Entity) bool {
+		entities = append(entities, entity)
+		return true
+	})
+	return entities
+}
+
+// GetSource 反向获取关联目标实体的第一个源实体，适用于一对多、一对一关系
+func (mgr *_EntityManagerBehavior) GetSource(kind string, targetId uid.Id) (ec.Entity, bool) {
+	var source ec.Entity
+	mgr.RangeSources(kind, targetId, func(entity ec.Entity) bool {
+		source = entity
+		return false
+	})
+	return source, source != nil
+}
+
+// CountSources 反向获取关联目标实体的源实体数量
+func (mgr *_EntityManagerBehavior) CountSources(kind string, targetId uid.Id) int {
+	relation, ok := mgr.relations[kind]
+	if !ok {
+		return 0
+	}
+	return len(relation.sources[targetId])
+}
+
+func (mgr *_EntityManagerBehavior) fetchRelationEntity(id uid.Id) (ec.Entity, error) {
+	entity, ok := mgr.GetEntity(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: entity %q not exist", ErrEntityRelation, id)
+	}
+
+	if entity.GetState() > ec.EntityState_Alive {
+		return nil, fmt.Errorf("%w: invalid entity %q state %q", ErrEntityRelation, entity.GetId(), entity.GetState())
+	}
+
+	return entity, nil
+}
+
+func (mgr *_EntityManagerBehavior) rangeRelationEntities(ids []uid.Id, fun generic.Func1[ec.Entity, bool]) {
+	for _, id := range ids {
+		entityNode, ok := mgr.entityIndex[id]
+		if !ok {
+			continue
+		}
+		if !fun.UnsafeCall(iface.Cache2Iface[ec.Entity](entityNode.V.Cache)) {
+			return
+		}
+	}
+}
+
+func (mgr *_EntityManagerBehavior) unlink(kind string, relation *_EntityRelation, sourceId, targetId uid.Id) bool {
+	targets := relation.targets[sourceId]
+
+	idx := slices.Index(targets, targetId)
+	if idx < 0 {
+		return false
+	}
+
+	if targets = slices.Delete(targets, idx, idx+1); len(targets) > 0 {
+		relation.targets[sourceId] = targets
+	} else {
+		delete(relation.targets, sourceId)
+	}
+
+	sources := relation.sources[targetId]
+	if idx := slices.Index(sources, sourceId); idx >= 0 {
+		sources = slices.Delete(sources, idx, idx+1)
+	}
+	if len(sources) > 0 {
+		relation.sources[targetId] = sources
+	} else {
+		delete(relation.sources, targetId)
+	}
+
+	sourceNode, ok := mgr.entityIndex[sourceId]
+	if !ok {
+		return true
+	}
+
+	targetNode, ok := mgr.entityIndex[targetId]
+	if !ok {
+		return true
+	}
+
+	_EmitEventEntityRelationUnlink(mgr, mgr, kind, iface.Cache2Iface[ec.Entity](sourceNode.V.Cache), iface.Cache2Iface[ec.Entity](targetNode.V.Cache))
+
+	return true
+}
+
+func (mgr *_EntityManagerBehavior) unlinkAllRelations(entity ec.Entity) {
+	for _, kind := range slices.Sorted(maps.Keys(mgr.relations)) {
+		mgr.UnlinkAll(kind, entity.GetId())
+	}
+}
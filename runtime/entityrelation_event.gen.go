@@ -0,0 +1,119 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Code generated by eventc event; DO NOT EDIT.
+
+package runtime
+
+import (
+	event "git.golaxy.org/core/event"
+	"git.golaxy.org/core/ec"
+)
+
+type iAutoEventEntityRelationLink interface {
+	EventEntityRelationLink() event.IEvent
+}
+
+func BindEventEntityRelationLink(auto iAutoEventEntityRelationLink, subscriber EventEntityRelationLink, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityRelationLink](auto.EventEntityRelationLink(), subscriber, priority...)
+}
+
+func _EmitEventEntityRelationLink(auto iAutoEventEntityRelationLink, entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityRelationLink()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityRelationLink](subscriber).OnEntityRelationLink(entityRelations, kind, source, target)
+		return true
+	})
+}
+
+func _EmitEventEntityRelationLinkWithInterrupt(auto iAutoEventEntityRelationLink, interrupt func(entityRelations EntityRelations, kind string, source, target ec.Entity) bool, entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityRelationLink()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityRelations, kind, source, target) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityRelationLink](subscriber).OnEntityRelationLink(entityRelations, kind, source, target)
+		return true
+	})
+}
+
+func HandleEventEntityRelationLink(fun func(entityRelations EntityRelations, kind string, source, target ec.Entity)) EventEntityRelationLinkHandler {
+	return EventEntityRelationLinkHandler(fun)
+}
+
+type EventEntityRelationLinkHandler func(entityRelations EntityRelations, kind string, source, target ec.Entity)
+
+func (h EventEntityRelationLinkHandler) OnEntityRelationLink(entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	h(entityRelations, kind, source, target)
+}
+
+type iAutoEventEntityRelationUnlink interface {
+	EventEntityRelationUnlink() event.IEvent
+}
+
+func BindEventEntityRelationUnlink(auto iAutoEventEntityRelationUnlink, subscriber EventEntityRelationUnlink, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityRelationUnlink](auto.EventEntityRelationUnlink(), subscriber, priority...)
+}
+
+func _EmitEventEntityRelationUnlink(auto iAutoEventEntityRelationUnlink, entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityRelationUnlink()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityRelationUnlink](subscriber).OnEntityRelationUnlink(entityRelations, kind, source, target)
+		return true
+	})
+}
+
+func _EmitEventEntityRelationUnlinkWithInterrupt(auto iAutoEventEntityRelationUnlink, interrupt func(entityRelations EntityRelations, kind string, source, target ec.Entity) bool, entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityRelationUnlink()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityRelations, kind, source, target) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityRelationUnlink](subscriber).OnEntityRelationUnlink(entityRelations, kind, source, target)
+		return true
+	})
+}
+
+func HandleEventEntityRelationUnlink(fun func(entityRelations EntityRelations, kind string, source, target ec.Entity)) EventEntityRelationUnlinkHandler {
+	return EventEntityRelationUnlinkHandler(fun)
+}
+
+type EventEntityRelationUnlinkHandler func(entityRelations EntityRelations, kind string, source, target ec.Entity)
+
+func (h EventEntityRelationUnlinkHandler) OnEntityRelationUnlink(entityRelations EntityRelations, kind string, source, target ec.Entity) {
+	h(entityRelations, kind, source, target)
+}
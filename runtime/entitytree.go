@@ -35,6 +35,8 @@ type EntityTree interface {
 
 	// AddNode 新增实体节点，会向实体管理器添加实体
 	AddNode(entity ec.Entity, parentId uid.Id) error
+	// AddNodeAt 新增实体节点，并插入至兄弟实体中的指定位置，位置超出范围时插入至末尾，会向实体管理器添加实体
+	AddNodeAt(entity ec.Entity, parentId uid.Id, idx int) error
 	// PruningNode 实体树节点剪枝，使实体成为根节点
 	PruningNode(entityId uid.Id) error
 	// RangeChildren 遍历子实体
@@ -51,6 +53,14 @@ type EntityTree interface {
 	ChangeParent(entityId, parentId uid.Id) error
 	// GetParent 获取父实体
 	GetParent(entityId uid.Id) (ec.Entity, bool)
+	// GetSiblingIndex 获取实体在兄弟实体中的位置
+	GetSiblingIndex(entityId uid.Id) (int, error)
+	// SetSiblingIndex 设置实体在兄弟实体中的位置，位置超出范围时移动至末尾
+	SetSiblingIndex(entityId uid.Id, idx int) error
+	// MoveBefore 移动实体至兄弟实体之前
+	MoveBefore(entityId, siblingId uid.Id) error
+	// MoveAfter 移动实体至兄弟实体之后
+	MoveAfter(entityId, siblingId uid.Id) error
 
 	IEntityTreeEventTab
 }
@@ -60,7 +70,18 @@ func (mgr *_EntityManagerBehavior) AddNode(entity ec.Entity, parentId uid.Id) er
 	if parentId.IsNil() {
 		return fmt.Errorf("%w: %w: parentId is nil", ErrEntityTree, exception.ErrArgs)
 	}
-	return mgr.addEntity(entity, parentId)
+	return mgr.addEntity(entity, parentId, -1)
+}
+
+// AddNodeAt 新增实体节点，并插入至兄弟实体中的指定位置，位置超出范围时插入至末尾，会向实体管理器添加实体
+func (mgr *_EntityManagerBehavior) AddNodeAt(entity ec.Entity, parentId uid.Id, idx int) error {
+	if parentId.IsNil() {
+		return fmt.Errorf("%w: %w: parentId is nil", ErrEntityTree, exception.ErrArgs)
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w: %w: idx less than 0", ErrEntityTree, exception.ErrArgs)
+	}
+	return mgr.addEntity(entity, parentId, idx)
 }
 
 // PruningNode 实体树节点剪枝，使实体成为根节点
@@ -188,7 +209,7 @@ func (mgr *_EntityManagerBehavior) ChangeParent(entityId, parentId uid.Id) error
 
 	switch entity.GetTreeNodeState() {
 	case ec.TreeNodeState_Freedom:
-		if err := mgr.appendToParentNode(entity, parent); err != nil {
+		if err := mgr.appendToParentNode(entity, parent, -1); err != nil {
 			return err
 		}
 
@@ -227,6 +248,167 @@ func (mgr *_EntityManagerBehavior) GetParent(entityId uid.Id) (ec.Entity, bool)
 	return entity.GetTreeNodeParent()
 }
 
+// GetSiblingIndex 获取实体在兄弟实体中的位置
+func (mgr *_EntityManagerBehavior) GetSiblingIndex(entityId uid.Id) (int, error) {
+	_, _, entityNode, parentNode, err := mgr.fetchSibling(entityId)
+	if err != nil {
+		return -1, err
+	}
+	return mgr.getChildIndex(parentNode, entityNode.parentAt), nil
+}
+
+// SetSiblingIndex 设置实体在兄弟实体中的位置，位置超出范围时移动至末尾
+func (mgr *_EntityManagerBehavior) SetSiblingIndex(entityId uid.Id, idx int) error {
+	if idx < 0 {
+		return fmt.Errorf("%w: %w: idx less than 0", ErrEntityTree, exception.ErrArgs)
+	}
+
+	entity, parent, entityNode, parentNode, err := mgr.fetchSibling(entityId)
+	if err != nil {
+		return err
+	}
+
+	from := mgr.getChildIndex(parentNode, entityNode.parentAt)
+
+	to := idx
+	if to >= parentNode.children.Len() {
+		to = parentNode.children.Len() - 1
+	}
+
+	if to == from {
+		return nil
+	}
+
+	at := mgr.getChildNode(parentNode, to)
+
+	if to < from {
+		parentNode.children.MoveBefore(entityNode.parentAt, at)
+	} else {
+		parentNode.children.MoveAfter(entityNode.parentAt, at)
+	}
+
+	mgr.reorderNode(parent, entity, from, to)
+
+	return nil
+}
+
+// MoveBefore 移动实体至兄弟实体之前
+func (mgr *_EntityManagerBehavior) MoveBefore(entityId, siblingId uid.Id) error {
+	return mgr.moveToSibling(entityId, siblingId, true)
+}
+
+// MoveAfter 移动实体至兄弟实体之后
+func (mgr *_EntityManagerBehavior) MoveAfter(entityId, siblingId uid.Id) error {
+	return mgr.moveToSibling(entityId, siblingId, false)
+}
+
+func (mgr *_EntityManagerBehavior) moveToSibling(entityId, siblingId uid.Id, before bool) error {
+	if entityId == siblingId {
+		return fmt.Errorf("%w: entity and sibling %q can't be the same", ErrEntityTree, entityId)
+	}
+
+	entity, parent, entityNode, parentNode, err := mgr.fetchSibling(entityId)
+	if err != nil {
+		return err
+	}
+
+	_, siblingParent, siblingNode, _, err := mgr.fetchSibling(siblingId)
+	if err != nil {
+		return err
+	}
+
+	if siblingParent.GetId() != parent.GetId() {
+		return fmt.Errorf("%w: entity %q and %q are not siblings", ErrEntityTree, entityId, siblingId)
+	}
+
+	from := mgr.getChildIndex(parentNode, entityNode.parentAt)
+
+	if before {
+		parentNode.children.MoveBefore(entityNode.parentAt, siblingNode.parentAt)
+	} else {
+		parentNode.children.MoveAfter(entityNode.parentAt, siblingNode.parentAt)
+	}
+
+	to := mgr.getChildIndex(parentNode, entityNode.parentAt)
+
+	if to == from {
+		return nil
+	}
+
+	mgr.reorderNode(parent, entity, from, to)
+
+	return nil
+}
+
+func (mgr *_EntityManagerBehavior) fetchSibling(entityId uid.Id) (ec.Entity, ec.Entity, *_TreeNode, *_TreeNode, error) {
+	entity, ok := mgr.GetEntity(entityId)
+	if !ok {
+		return nil, nil, nil, nil, fmt.Errorf("%w: entity %q not exist", ErrEntityTree, entityId)
+	}
+
+	if entity.GetState() > ec.EntityState_Alive {
+		return nil, nil, nil, nil, fmt.Errorf("%w: invalid entity %q state %q", ErrEntityTree, entity.GetId(), entity.GetState())
+	}
+
+	if entity.GetTreeNodeState() != ec.TreeNodeState_Attached {
+		return nil, nil, nil, nil, fmt.Errorf("%w: invalid entity %q tree node state %q", ErrEntityTree, entity.GetId(), entity.GetTreeNodeState())
+	}
+
+	parent, ok := entity.GetTreeNodeParent()
+	if !ok {
+		return nil, nil, nil, nil, fmt.Errorf("%w: entity %q parent not exist", ErrEntityTree, entity.GetId())
+	}
+
+	entityNode, ok := mgr.treeNodes[entity.GetId()]
+	if !ok || entityNode.parentAt == nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: entity %q not exist in entity-tree", ErrEntityTree, entity.GetId())
+	}
+
+	parentNode, ok := mgr.treeNodes[parent.GetId()]
+	if !ok || parentNode.children == nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: parent %q not exist in entity-tree", ErrEntityTree, parent.GetId())
+	}
+
+	return entity, parent, entityNode, parentNode, nil
+}
+
+func (mgr *_EntityManagerBehavior) getChildIndex(parentNode *_TreeNode, childNode *generic.Node[iface.FaceAny]) int {
+	idx, pos := -1, 0
+
+	parentNode.children.Traversal(func(node *generic.Node[iface.FaceAny]) bool {
+		if node == childNode {
+			idx = pos
+			return false
+		}
+		pos++
+		return true
+	})
+
+	return idx
+}
+
+func (mgr *_EntityManagerBehavior) getChildNode(parentNode *_TreeNode, idx int) *generic.Node[iface.FaceAny] {
+	var childNode *generic.Node[iface.FaceAny]
+	pos := 0
+
+	parentNode.children.Traversal(func(node *generic.Node[iface.FaceAny]) bool {
+		if pos == idx {
+			childNode = node
+			return false
+		}
+		pos++
+		return true
+	})
+
+	return childNode
+}
+
+func (mgr *_EntityManagerBehavior) reorderNode(parent, entity ec.Entity, from, to int) {
+	_EmitEventEntityTreeReorderNodeWithInterrupt(mgr, func(entityTree EntityTree, parent, child ec.Entity, from, to int) bool {
+		return parent.GetState() > ec.EntityState_Alive || child.GetState() > ec.EntityState_Alive
+	}, mgr, parent, entity, from, to)
+}
+
 func (mgr *_EntityManagerBehavior) changeToParentNode(entity, parent ec.Entity) error {
 	if entity == nil {
 		exception.Panicf("%w: %w: entity is nil", ErrEntityTree, exception.ErrArgs)
@@ -252,7 +434,7 @@ func (mgr *_EntityManagerBehavior) changeToParentNode(entity, parent ec.Entity)
 
 	mgr.detachFromParentNode(entity)
 
-	if err := mgr.appendToParentNode(entity, parent); err != nil {
+	if err := mgr.appendToParentNode(entity, parent, -1); err != nil {
 		return err
 	}
 
@@ -263,7 +445,7 @@ func (mgr *_EntityManagerBehavior) changeToParentNode(entity, parent ec.Entity)
 	return nil
 }
 
-func (mgr *_EntityManagerBehavior) appendToParentNode(entity, parent ec.Entity) error {
+func (mgr *_EntityManagerBehavior) appendToParentNode(entity, parent ec.Entity, idx int) error {
 	if entity == nil {
 		exception.Panicf("%w: %w: entity is nil", ErrEntityTree, exception.ErrArgs)
 	}
@@ -302,7 +484,15 @@ func (mgr *_EntityManagerBehavior) appendToParentNode(entity, parent ec.Entity)
 		entityNode.parentAt.Escape()
 		entityNode.parentAt = nil
 	}
-	entityNode.parentAt = parentNode.children.PushBack(iface.MakeFaceAny(entity))
+	var at *generic.Node[iface.FaceAny]
+	if idx >= 0 {
+		at = mgr.getChildNode(parentNode, idx)
+	}
+	if at != nil {
+		entityNode.parentAt = parentNode.children.InsertBefore(iface.MakeFaceAny(entity), at)
+	} else {
+		entityNode.parentAt = parentNode.children.PushBack(iface.MakeFaceAny(entity))
+	}
 
 	ec.UnsafeEntity(entity).SetTreeNodeState(ec.TreeNodeState_Attaching)
 	ec.UnsafeEntity(entity).SetTreeNodeParent(parent)
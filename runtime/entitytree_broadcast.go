@@ -0,0 +1,84 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+)
+
+// BroadcastTree 向实体与其在实体树中的所有子孙实体广播消息，先父后子深度优先，每个实体内的广播规则与ec.Broadcast相同
+func BroadcastTree[I any](entity ec.Entity, fun generic.Action1[I]) {
+	rangeTree(entity, func(entity ec.Entity) bool {
+		ec.Broadcast[I](entity, fun)
+		return true
+	})
+}
+
+// BroadcastTreeFirst 向实体与其在实体树中的所有子孙实体广播消息，首个返回true的组件响应后停止广播，返回是否有组件响应
+func BroadcastTreeFirst[I any](entity ec.Entity, fun generic.Func1[I, bool]) bool {
+	handled := false
+
+	rangeTree(entity, func(entity ec.Entity) bool {
+		handled = ec.BroadcastFirst[I](entity, fun)
+		return !handled
+	})
+
+	return handled
+}
+
+// BroadcastTreeCollect 向实体与其在实体树中的所有子孙实体广播消息，并收集所有组件的返回值
+func BroadcastTreeCollect[I, R any](entity ec.Entity, fun generic.Func1[I, R]) []R {
+	var rets []R
+
+	rangeTree(entity, func(entity ec.Entity) bool {
+		rets = append(rets, ec.BroadcastCollect[I, R](entity, fun)...)
+		return true
+	})
+
+	return rets
+}
+
+func rangeTree(entity ec.Entity, fun func(entity ec.Entity) bool) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEntityTree, exception.ErrArgs)
+	}
+
+	var visit func(entity ec.Entity) bool
+	tree := Current(entity).GetEntityTree()
+
+	visit = func(entity ec.Entity) bool {
+		if !fun(entity) {
+			return false
+		}
+
+		ret := true
+
+		tree.RangeChildren(entity.GetId(), func(child ec.Entity) bool {
+			ret = visit(child)
+			return ret
+		})
+
+		return ret
+	}
+
+	visit(entity)
+}
@@ -117,3 +117,49 @@ type EventEntityTreeRemoveNodeHandler func(entityTree EntityTree, parent, child
 func (h EventEntityTreeRemoveNodeHandler) OnEntityTreeRemoveNode(entityTree EntityTree, parent, child ec.Entity) {
 	h(entityTree, parent, child)
 }
+
+type iAutoEventEntityTreeReorderNode interface {
+	EventEntityTreeReorderNode() event.IEvent
+}
+
+func BindEventEntityTreeReorderNode(auto iAutoEventEntityTreeReorderNode, subscriber EventEntityTreeReorderNode, priority ...int32) event.Hook {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityTreeReorderNode](auto.EventEntityTreeReorderNode(), subscriber, priority...)
+}
+
+func _EmitEventEntityTreeReorderNode(auto iAutoEventEntityTreeReorderNode, entityTree EntityTree, parent, child ec.Entity, from, to int) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityTreeReorderNode()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityTreeReorderNode](subscriber).OnEntityTreeReorderNode(entityTree, parent, child, from, to)
+		return true
+	})
+}
+
+func _EmitEventEntityTreeReorderNodeWithInterrupt(auto iAutoEventEntityTreeReorderNode, interrupt func(entityTree EntityTree, parent, child ec.Entity, from, to int) bool, entityTree EntityTree, parent, child ec.Entity, from, to int) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityTreeReorderNode()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityTree, parent, child, from, to) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityTreeReorderNode](subscriber).OnEntityTreeReorderNode(entityTree, parent, child, from, to)
+		return true
+	})
+}
+
+func HandleEventEntityTreeReorderNode(fun func(entityTree EntityTree, parent, child ec.Entity, from, to int)) EventEntityTreeReorderNodeHandler {
+	return EventEntityTreeReorderNodeHandler(fun)
+}
+
+type EventEntityTreeReorderNodeHandler func(entityTree EntityTree, parent, child ec.Entity, from, to int)
+
+func (h EventEntityTreeReorderNodeHandler) OnEntityTreeReorderNode(entityTree EntityTree, parent, child ec.Entity, from, to int) {
+	h(entityTree, parent, child, from, to)
+}
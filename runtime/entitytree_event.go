@@ -36,3 +36,9 @@ type EventEntityTreeAddNode interface {
 type EventEntityTreeRemoveNode interface {
 	OnEntityTreeRemoveNode(entityTree EntityTree, parent, child ec.Entity)
 }
+
+// EventEntityTreeReorderNode 事件：实体树节点调整兄弟位置
+// +event-gen:export=0
+type EventEntityTreeReorderNode interface {
+	OnEntityTreeReorderNode(entityTree EntityTree, parent, child ec.Entity, from, to int)
+}
@@ -28,19 +28,22 @@ import (
 type IEntityTreeEventTab interface {
 	EventEntityTreeAddNode() event.IEvent
 	EventEntityTreeRemoveNode() event.IEvent
+	EventEntityTreeReorderNode() event.IEvent
 }
 
 var (
 	_entityTreeEventTabId = event.DeclareEventTabIdT[entityTreeEventTab]()
 	EventEntityTreeAddNodeId = _entityTreeEventTabId + 0
 	EventEntityTreeRemoveNodeId = _entityTreeEventTabId + 1
+	EventEntityTreeReorderNodeId = _entityTreeEventTabId + 2
 )
 
-type entityTreeEventTab [2]event.Event
+type entityTreeEventTab [3]event.Event
 
 func (eventTab *entityTreeEventTab) Init(autoRecover bool, reportError chan error, recursion event.EventRecursion) {
 	(*eventTab)[0].Init(autoRecover, reportError, recursion)
 	(*eventTab)[1].Init(autoRecover, reportError, recursion)
+	(*eventTab)[2].Init(autoRecover, reportError, recursion)
 }
 
 func (eventTab *entityTreeEventTab) Open() {
@@ -83,3 +86,7 @@ func (eventTab *entityTreeEventTab) EventEntityTreeAddNode() event.IEvent {
 func (eventTab *entityTreeEventTab) EventEntityTreeRemoveNode() event.IEvent {
 	return &(*eventTab)[1]
 }
+
+func (eventTab *entityTreeEventTab) EventEntityTreeReorderNode() event.IEvent {
+	return &(*eventTab)[2]
+}
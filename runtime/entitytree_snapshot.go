@@ -0,0 +1,54 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/exception"
+)
+
+// TakeEntityTreeSnapshot 获取实体快照，包含实体在实体树中的所有子孙实体
+func TakeEntityTreeSnapshot(entity ec.Entity) (*ec.EntitySnapshot, error) {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrEntityTree, exception.ErrArgs)
+	}
+
+	snapshot, err := ec.TakeEntitySnapshot(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	Current(entity).GetEntityTree().RangeChildren(entity.GetId(), func(child ec.Entity) bool {
+		var childSnapshot *ec.EntitySnapshot
+
+		childSnapshot, err = TakeEntityTreeSnapshot(child)
+		if err != nil {
+			return false
+		}
+
+		snapshot.Children = append(snapshot.Children, childSnapshot)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
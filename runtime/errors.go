@@ -25,8 +25,11 @@ import (
 )
 
 var (
-	ErrContext       = fmt.Errorf("%w: runtime-context", exception.ErrCore) // 运行时上下文错误
-	ErrEntityTree    = fmt.Errorf("%w: entity-tree", ErrContext)            // 实体树错误
-	ErrEntityManager = fmt.Errorf("%w: entity-manager", ErrContext)         // 实体管理器错误
-	ErrFrame         = fmt.Errorf("%w: frame", ErrContext)                  // 帧错误
+	ErrContext        = fmt.Errorf("%w: runtime-context", exception.ErrCore) // 运行时上下文错误
+	ErrEntityTree     = fmt.Errorf("%w: entity-tree", ErrContext)            // 实体树错误
+	ErrEntityManager  = fmt.Errorf("%w: entity-manager", ErrContext)         // 实体管理器错误
+	ErrEntityRelation = fmt.Errorf("%w: entity-relation", ErrContext)        // 实体关系错误
+	ErrEntityGroup    = fmt.Errorf("%w: entity-group", ErrContext)           // 实体分组错误
+	ErrDeltaStream    = fmt.Errorf("%w: delta-stream", ErrContext)           // 增量复制流错误
+	ErrFrame          = fmt.Errorf("%w: frame", ErrContext)                  // 帧错误
 )
@@ -22,6 +22,7 @@ package runtime
 var With _Option
 
 type _Option struct {
-	Context _ContextOption // 运行时上下文的选项设置器
-	Frame   _FrameOption   // 帧的选项设置器
+	Context     _ContextOption     // 运行时上下文的选项设置器
+	Frame       _FrameOption       // 帧的选项设置器
+	DeltaStream _DeltaStreamOption // 增量复制流的选项设置器
 }
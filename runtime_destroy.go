@@ -0,0 +1,160 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/generic"
+	"sync"
+	"time"
+)
+
+// deferredDestroyRetryDelay 任务处理流水线已满时，重试压入延迟销毁任务的间隔
+const deferredDestroyRetryDelay = 10 * time.Millisecond
+
+type _DeferredDestroyTimers struct {
+	mutex   sync.Mutex
+	handle  int64
+	timers  map[int64]*time.Timer
+	stopped bool
+}
+
+func (ts *_DeferredDestroyTimers) afterFunc(delay time.Duration, fun func()) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if ts.stopped {
+		return
+	}
+
+	if ts.timers == nil {
+		ts.timers = map[int64]*time.Timer{}
+	}
+
+	ts.handle++
+	handle := ts.handle
+
+	ts.timers[handle] = time.AfterFunc(delay, func() {
+		ts.mutex.Lock()
+		_, ok := ts.timers[handle]
+		delete(ts.timers, handle)
+		ts.mutex.Unlock()
+
+		if ok {
+			fun()
+		}
+	})
+}
+
+func (ts *_DeferredDestroyTimers) stopAll() {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	ts.stopped = true
+
+	for _, timer := range ts.timers {
+		timer.Stop()
+	}
+	ts.timers = nil
+}
+
+// onEntityDestroyDeferred 事件处理器：实体延迟销毁自身
+func (rt *RuntimeBehavior) onEntityDestroyDeferred(entity ec.Entity, delay time.Duration) {
+	if entity.GetState() > ec.EntityState_Alive {
+		return
+	}
+
+	handle := entity.GetHandle()
+
+	if delay <= 0 {
+		rt.pushPendingDestroy(handle)
+		return
+	}
+
+	rt.deferredDestroyTimers.afterFunc(delay, func() {
+		rt.pushDeferredDestroyTask(handle)
+	})
+}
+
+func (rt *RuntimeBehavior) pushDeferredDestroyTask(handle ec.EntityHandle) {
+	ret := (<-rt.PushCallVoidAsync(func(...any) {
+		rt.pushPendingDestroy(handle)
+	}))
+	switch {
+	case ret.OK():
+		return
+	case errors.Is(ret.Error, ErrProcessQueueFull):
+		rt.deferredDestroyTimers.afterFunc(deferredDestroyRetryDelay, func() {
+			rt.pushDeferredDestroyTask(handle)
+		})
+	case errors.Is(ret.Error, ErrProcessQueueClosed):
+		return
+	default:
+		ictx.ReportError(rt.ctx, fmt.Errorf("%w: entity %q deferred destroy failed, %w", ErrRuntime, handle.Id, ret.Error))
+	}
+}
+
+func (rt *RuntimeBehavior) pushPendingDestroy(handle ec.EntityHandle) {
+	if _, ok := rt.pendingDestroyIndex[handle]; ok {
+		return
+	}
+
+	rt.pendingDestroyIndex[handle] = struct{}{}
+	rt.pendingDestroyList = append(rt.pendingDestroyList, handle)
+}
+
+func (rt *RuntimeBehavior) destroyPendingEntities() {
+	for len(rt.pendingDestroyList) > 0 {
+		handles := rt.pendingDestroyList
+		rt.pendingDestroyList = nil
+
+		for _, handle := range handles {
+			delete(rt.pendingDestroyIndex, handle)
+
+			// 按句柄解析，实体已销毁或Id已被新实体复用时不会误删
+			if _, ok := handle.Resolve(rt.ctx.GetEntityManager()); !ok {
+				continue
+			}
+
+			rt.ctx.GetEntityManager().RemoveEntity(handle.Id)
+		}
+	}
+}
+
+func (rt *RuntimeBehavior) pushPendingRelease(entity ec.Entity) {
+	if entity.GetPT().PoolCapacity() <= 0 {
+		return
+	}
+	rt.pendingReleaseList = append(rt.pendingReleaseList, entity)
+}
+
+func (rt *RuntimeBehavior) releasePendingEntities() {
+	for len(rt.pendingReleaseList) > 0 {
+		entities := rt.pendingReleaseList
+		rt.pendingReleaseList = nil
+
+		for _, entity := range entities {
+			generic.CastAction1(entity.GetPT().Release).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError(), entity)
+		}
+	}
+}
@@ -112,6 +112,8 @@ func (rt *RuntimeBehavior) frameLoopBegin() {
 }
 
 func (rt *RuntimeBehavior) frameLoopEnd() {
+	rt.destroyPendingEntities()
+
 	rt.changeRunningStatus(runtime.RunningStatus_FrameLoopEnd)
 
 	frame := runtime.UnsafeFrame(rt.opts.Frame)
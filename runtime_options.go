@@ -40,6 +40,7 @@ type RuntimeOptions struct {
 	Frame                runtime.Frame       // 帧，设置为nil表示不使用帧更新特性
 	GCInterval           time.Duration       // GC间隔时长
 	CustomGC             CustomGC            // 自定义GC
+	LiveUpgrade          bool                // 是否开启实体在线升级，开启后实体原型重声明时将升级运行时中的存量实体
 }
 
 type _RuntimeOption struct{}
@@ -53,6 +54,7 @@ func (_RuntimeOption) Default() option.Setting[RuntimeOptions] {
 		With.Runtime.Frame(nil)(o)
 		With.Runtime.GCInterval(10 * time.Second)(o)
 		With.Runtime.CustomGC(nil)(o)
+		With.Runtime.LiveUpgrade(false)(o)
 	}
 }
 
@@ -103,3 +105,10 @@ func (_RuntimeOption) CustomGC(fn CustomGC) option.Setting[RuntimeOptions] {
 		o.CustomGC = fn
 	}
 }
+
+// LiveUpgrade 运行时是否开启实体在线升级，开启后实体原型重声明时将升级运行时中的存量实体
+func (_RuntimeOption) LiveUpgrade(b bool) option.Setting[RuntimeOptions] {
+	return func(o *RuntimeOptions) {
+		o.LiveUpgrade = b
+	}
+}
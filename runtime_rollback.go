@@ -0,0 +1,81 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/generic"
+	"slices"
+)
+
+func (rt *RuntimeBehavior) callLifecycleE(fun func() error) error {
+	err, panicErr := generic.CastFunc0(fun).Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
+	if panicErr != nil {
+		return panicErr
+	}
+	return err
+}
+
+// rollbackEntity 实体激活失败时，反序回滚已唤醒的组件，并从实体管理器中删除实体
+func (rt *RuntimeBehavior) rollbackEntity(entity ec.Entity, err error) {
+	ec.UnsafeEntity(entity).SetLifecycleError(err)
+
+	var comps []ec.Component
+	ec.UnsafeEntity(entity).RangeAllComponents(func(comp ec.Component) bool {
+		if comp.GetState() > ec.ComponentState_Attach && comp.GetState() < ec.ComponentState_Detach {
+			comps = append(comps, comp)
+		}
+		return true
+	})
+	slices.Reverse(comps)
+
+	for _, comp := range comps {
+		switch {
+		case comp.GetState() == ec.ComponentState_Awake:
+			ec.UnsafeComponent(comp).SetState(ec.ComponentState_Death)
+			ec.UnsafeComponent(comp).SetState(ec.ComponentState_Destroyed)
+		case ec.UnsafeComponent(comp).GetProcessedStateBits().Is(int8(ec.ComponentState_Alive)):
+			ec.UnsafeComponent(comp).SetState(ec.ComponentState_Shut)
+		default:
+			ec.UnsafeComponent(comp).SetState(ec.ComponentState_Disable)
+		}
+	}
+
+	for _, comp := range comps {
+		rt.shutComponent(comp)
+	}
+
+	for _, comp := range comps {
+		rt.disableDeathComponent(comp)
+	}
+
+	for _, comp := range comps {
+		rt.disposeComponent(comp)
+	}
+
+	rt.ctx.GetEntityManager().RemoveEntity(entity.GetId())
+}
+
+// failComponent 组件唤醒或开始失败时，报告错误并删除组件
+func (rt *RuntimeBehavior) failComponent(comp ec.Component, err error) {
+	ictx.ReportError(rt.ctx, err)
+	ec.UnsafeEntity(comp.GetEntity()).RemoveComponentByRef(comp)
+}
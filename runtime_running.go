@@ -105,7 +105,11 @@ func (rt *RuntimeBehavior) changeRunningStatus(status runtime.RunningStatus, arg
 	switch status {
 	case runtime.RunningStatus_Starting:
 		rt.initAddIn()
+		rt.initLiveUpgrade()
+	case runtime.RunningStatus_Terminating:
+		rt.deferredDestroyTimers.stopAll()
 	case runtime.RunningStatus_Terminated:
+		rt.shutLiveUpgrade()
 		rt.shutAddIn()
 	}
 }
@@ -195,6 +199,7 @@ func (rt *RuntimeBehavior) loopStart() []event.Hook {
 		runtime.BindEventEntityManagerRemoveEntity(ctx.GetEntityManager(), rt.handleEventEntityManagerRemoveEntity),
 		runtime.BindEventEntityManagerEntityAddComponents(ctx.GetEntityManager(), rt.handleEventEntityManagerEntityAddComponents),
 		runtime.BindEventEntityManagerEntityRemoveComponent(ctx.GetEntityManager(), rt.handleEventEntityManagerEntityRemoveComponent),
+		runtime.BindEventEntityManagerEntityReplaceComponent(ctx.GetEntityManager(), rt.handleEventEntityManagerEntityReplaceComponent),
 		runtime.BindEventEntityManagerEntityFirstTouchComponent(ctx.GetEntityManager(), rt.handleEventEntityManagerEntityFirstTouchComponent),
 	}
 }
@@ -224,14 +229,20 @@ func (rt *RuntimeBehavior) runTask(task _Task) {
 	case _TaskType_Call:
 		rt.changeRunningStatus(runtime.RunningStatus_RunCallBegin)
 		task.run(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
+		if rt.opts.Frame == nil {
+			rt.destroyPendingEntities()
+		}
 		rt.changeRunningStatus(runtime.RunningStatus_RunCallEnd)
 	case _TaskType_Frame:
 		task.run(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError())
 	}
+
+	rt.releasePendingEntities()
 }
 
 func (rt *RuntimeBehavior) runGC() {
 	rt.changeRunningStatus(runtime.RunningStatus_RunGCBegin)
 	rt.gc()
+	rt.releasePendingEntities()
 	rt.changeRunningStatus(runtime.RunningStatus_RunGCEnd)
 }
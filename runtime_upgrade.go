@@ -0,0 +1,178 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/ec/pt"
+	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/service"
+	"git.golaxy.org/core/utils/async"
+	"time"
+)
+
+// liveUpgradeRetryDelay 任务处理流水线已满时，重试压入在线升级任务的间隔
+const liveUpgradeRetryDelay = 10 * time.Millisecond
+
+type _UpgradingComponent struct {
+	idx  int
+	name string
+	comp ec.Component
+}
+
+func (rt *RuntimeBehavior) initLiveUpgrade() {
+	if !rt.opts.LiveUpgrade {
+		return
+	}
+
+	rt.unwatchEntityPT = service.UnsafeContext(service.Current(rt)).WatchRunningStatus(func(status service.RunningStatus, args ...any) {
+		if status != service.RunningStatus_EntityPTRedeclared {
+			return
+		}
+
+		rt.pushUpgradeTask(args[0].(ec.EntityPT))
+	})
+}
+
+func (rt *RuntimeBehavior) pushUpgradeTask(entityPT ec.EntityPT) {
+	asyncRet := rt.PushCallVoidAsync(func(...any) {
+		rt.upgradeEntities(entityPT)
+	})
+
+	// 压入失败时结果会立即返回，压入成功时不等待任务执行，避免在运行时线程中重声明实体原型时死锁
+	var ret async.Ret
+	select {
+	case ret = <-asyncRet:
+	default:
+		return
+	}
+
+	switch {
+	case ret.OK():
+		return
+	case errors.Is(ret.Error, ErrProcessQueueFull):
+		time.AfterFunc(liveUpgradeRetryDelay, func() {
+			rt.pushUpgradeTask(entityPT)
+		})
+	case errors.Is(ret.Error, ErrProcessQueueClosed):
+		return
+	default:
+		ictx.ReportError(rt.ctx, fmt.Errorf("%w: entity prototype %q live upgrade failed, %w", ErrRuntime, entityPT.Prototype(), ret.Error))
+	}
+}
+
+func (rt *RuntimeBehavior) shutLiveUpgrade() {
+	if rt.unwatchEntityPT == nil {
+		return
+	}
+	rt.unwatchEntityPT()
+	rt.unwatchEntityPT = nil
+}
+
+func (rt *RuntimeBehavior) upgradeEntities(entityPT ec.EntityPT) {
+	for _, entity := range rt.ctx.GetEntityManager().FilterEntities(func(entity ec.Entity) bool {
+		return entity.GetPT().Prototype() == entityPT.Prototype() && entity.GetPT() != entityPT
+	}) {
+		if entity.GetState() > ec.EntityState_Alive {
+			continue
+		}
+		if err := rt.upgradeEntity(entity, entityPT); err != nil {
+			ictx.ReportError(rt.ctx, err)
+		}
+	}
+}
+
+func (rt *RuntimeBehavior) upgradeEntity(entity ec.Entity, entityPT ec.EntityPT) error {
+	oldPT := entity.GetPT()
+	newBuiltins := entityPT.Components()
+	matched := make([]bool, len(newBuiltins))
+
+	var rebound []_UpgradingComponent
+	var removed []ec.Component
+
+	ec.UnsafeEntity(entity).RangeAllComponents(func(comp ec.Component) bool {
+		if comp.GetState() > ec.ComponentState_Alive {
+			return true
+		}
+
+		builtin := comp.GetBuiltin()
+		if builtin.Offset < 0 || builtin.Offset >= oldPT.CountComponents() || oldPT.Component(builtin.Offset).Name != comp.GetName() {
+			return true
+		}
+
+		for i := range newBuiltins {
+			if matched[i] || newBuiltins[i].Name != comp.GetName() || newBuiltins[i].PT.Prototype() != builtin.PT.Prototype() {
+				continue
+			}
+			matched[i] = true
+			rebound = append(rebound, _UpgradingComponent{idx: i, name: newBuiltins[i].Name, comp: comp})
+			return true
+		}
+
+		removed = append(removed, comp)
+		return true
+	})
+
+	var added []_UpgradingComponent
+
+	for i := range newBuiltins {
+		if matched[i] {
+			continue
+		}
+		added = append(added, _UpgradingComponent{idx: i, name: newBuiltins[i].Name, comp: pt.UnsafeEntityPT(entityPT).ConstructComponent(i)})
+	}
+
+	if migration := entityPT.Migration(); migration != nil {
+		addedComps := make([]ec.Component, 0, len(added))
+		for i := range added {
+			addedComps = append(addedComps, added[i].comp)
+		}
+
+		if err := migration.Call(rt.ctx.GetAutoRecover(), rt.ctx.GetReportError(), entity, oldPT, removed, addedComps); err != nil {
+			return fmt.Errorf("%w: entity %q upgrade migration failed, %w", ErrCore, entity.GetId(), err)
+		}
+	}
+
+	// 先添加新组件，任意组件添加失败时删除已添加的组件，此时旧组件与实体原型均未改变
+	for i := range added {
+		if err := entity.AddComponent(added[i].name, added[i].comp); err != nil {
+			for j := range i {
+				ec.UnsafeComponent(added[j].comp).SetRemovable(true)
+				ec.UnsafeEntity(entity).RemoveComponentByRef(added[j].comp)
+			}
+			return fmt.Errorf("%w: entity %q upgrade add component %q failed, %w", ErrCore, entity.GetId(), added[i].name, err)
+		}
+	}
+
+	for i := range rebound {
+		pt.UnsafeEntityPT(entityPT).RebindComponent(rebound[i].idx, rebound[i].comp)
+	}
+
+	for _, comp := range removed {
+		ec.UnsafeComponent(comp).SetRemovable(true)
+		ec.UnsafeEntity(entity).RemoveComponentByRef(comp)
+	}
+
+	ec.UnsafeEntity(entity).SetPT(entityPT)
+
+	return nil
+}
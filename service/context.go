@@ -25,6 +25,7 @@ import (
 	"git.golaxy.org/core/ec/pt"
 	"git.golaxy.org/core/extension"
 	"git.golaxy.org/core/internal/ictx"
+	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/option"
 	"git.golaxy.org/core/utils/reinterpret"
@@ -68,12 +69,15 @@ type Context interface {
 	GetReflected() reflect.Value
 	// GetEntityManager 获取实体管理器
 	GetEntityManager() EntityManager
+	// GetEntityGroups 获取全局实体分组
+	GetEntityGroups() EntityGroups
 }
 
 type iContext interface {
 	init(opts ContextOptions)
 	getOptions() *ContextOptions
 	changeRunningStatus(status RunningStatus, args ...any)
+	watchRunningStatus(fun generic.ActionVar1[RunningStatus, any]) func()
 }
 
 // ContextBehavior 服务上下文行为，在扩展服务上下文能力时，匿名嵌入至服务上下文结构体中
@@ -82,6 +86,8 @@ type ContextBehavior struct {
 	opts          ContextOptions
 	reflected     reflect.Value
 	entityManager _EntityManagerBehavior
+
+	runningStatusWatchers _RunningStatusWatchers
 }
 
 // GetName 获取名称
@@ -104,6 +110,11 @@ func (ctx *ContextBehavior) GetEntityManager() EntityManager {
 	return &ctx.entityManager
 }
 
+// GetEntityGroups 获取全局实体分组
+func (ctx *ContextBehavior) GetEntityGroups() EntityGroups {
+	return &ctx.entityManager
+}
+
 // GetInstanceFaceCache 支持重新解释类型
 func (ctx *ContextBehavior) GetInstanceFaceCache() iface.Cache {
 	return ctx.opts.InstanceFace.Cache
@@ -140,4 +151,5 @@ func (ctx *ContextBehavior) getOptions() *ContextOptions {
 
 func (ctx *ContextBehavior) changeRunningStatus(status RunningStatus, args ...any) {
 	ctx.opts.RunningHandler.Call(ctx.GetAutoRecover(), ctx.GetReportError(), nil, ctx.opts.InstanceFace.Iface, status, args...)
+	ctx.runningStatusWatchers.notify(ctx.GetAutoRecover(), ctx.GetReportError(), status, args...)
 }
@@ -0,0 +1,76 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package service
+
+import (
+	"git.golaxy.org/core/utils/generic"
+	"slices"
+	"sync"
+)
+
+type _RunningStatusWatcher struct {
+	handle int64
+	fun    generic.ActionVar1[RunningStatus, any]
+}
+
+type _RunningStatusWatchers struct {
+	mutex    sync.RWMutex
+	handle   int64
+	watchers []*_RunningStatusWatcher
+}
+
+func (ws *_RunningStatusWatchers) watch(fun generic.ActionVar1[RunningStatus, any]) func() {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	ws.handle++
+	handle := ws.handle
+
+	ws.watchers = append(ws.watchers, &_RunningStatusWatcher{
+		handle: handle,
+		fun:    fun,
+	})
+
+	return func() {
+		ws.mutex.Lock()
+		defer ws.mutex.Unlock()
+
+		ws.watchers = slices.DeleteFunc(ws.watchers, func(watcher *_RunningStatusWatcher) bool {
+			return watcher.handle == handle
+		})
+	}
+}
+
+func (ws *_RunningStatusWatchers) notify(autoRecover bool, reportError chan error, status RunningStatus, args ...any) {
+	ws.mutex.RLock()
+	watchers := slices.Clone(ws.watchers)
+	ws.mutex.RUnlock()
+
+	for _, watcher := range watchers {
+		watcher.fun.Call(autoRecover, reportError, status, args...)
+	}
+}
+
+func (ctx *ContextBehavior) watchRunningStatus(fun generic.ActionVar1[RunningStatus, any]) func() {
+	if fun == nil {
+		return func() {}
+	}
+	return ctx.runningStatusWatchers.watch(fun)
+}
@@ -0,0 +1,305 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package service
+
+import (
+	"fmt"
+	"git.golaxy.org/core/ec"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/uid"
+	"slices"
+	"sync"
+)
+
+// EntityGroups 全局实体分组接口，线程安全，用于将全局实体组织为具名的集合，实体从实体管理器中删除时，将自动离开其加入的所有分组
+type EntityGroups interface {
+	// GetContext 获取服务上下文
+	GetContext() Context
+	// AddToGroup 实体加入分组，实体需要在实体管理器中，分组不存在时将自动创建
+	AddToGroup(group string, entityId uid.Id) error
+	// RemoveFromGroup 实体离开分组，分组为空时将自动删除
+	RemoveFromGroup(group string, entityId uid.Id) bool
+	// DeleteGroup 删除分组，所有成员实体将离开分组
+	DeleteGroup(group string)
+	// ContainsInGroup 分组中是否包含实体
+	ContainsInGroup(group string, entityId uid.Id) bool
+	// RangeGroup 按加入顺序遍历分组中的实体
+	RangeGroup(group string, fun generic.Func1[ec.ConcurrentEntity, bool])
+	// GetGroupEntities 按加入顺序获取分组中的所有实体
+	GetGroupEntities(group string) []ec.ConcurrentEntity
+	// CountGroup 获取分组中的实体数量
+	CountGroup(group string) int
+	// GetJoinedGroups 获取实体加入的所有分组
+	GetJoinedGroups(entityId uid.Id) []string
+	// WatchGroupJoin 监听实体加入分组，返回取消监听的函数，回调在调用方的goroutine中执行
+	WatchGroupJoin(fun generic.Action2[string, ec.ConcurrentEntity]) func()
+	// WatchGroupLeave 监听实体离开分组，返回取消监听的函数，回调在调用方的goroutine中执行
+	WatchGroupLeave(fun generic.Action2[string, ec.ConcurrentEntity]) func()
+}
+
+type _EntityGroup struct {
+	members []ec.ConcurrentEntity
+	index   map[uid.Id]struct{}
+}
+
+type _EntityGroupMembership struct {
+	group  string
+	entity ec.ConcurrentEntity
+}
+
+type _EntityGroupWatcher struct {
+	handle int64
+	fun    generic.Action2[string, ec.ConcurrentEntity]
+}
+
+type _EntityGroupsBehavior struct {
+	mutex         sync.RWMutex
+	groups        map[string]*_EntityGroup
+	memberships   map[uid.Id][]string
+	watcherMutex  sync.RWMutex
+	watcherHandle int64
+	joinWatchers  []*_EntityGroupWatcher
+	leaveWatchers []*_EntityGroupWatcher
+}
+
+// AddToGroup 实体加入分组，实体需要在实体管理器中，分组不存在时将自动创建
+func (mgr *_EntityManagerBehavior) AddToGroup(group string, entityId uid.Id) error {
+	if group == "" {
+		return fmt.Errorf("%w: %w: group is empty", ErrEntityGroup, exception.ErrArgs)
+	}
+
+	mgr.groups.mutex.Lock()
+
+	entity, ok := mgr.GetEntity(entityId)
+	if !ok {
+		mgr.groups.mutex.Unlock()
+		return fmt.Errorf("%w: entity %q not exist", ErrEntityGroup, entityId)
+	}
+
+	entityGroup, ok := mgr.groups.groups[group]
+	if !ok {
+		entityGroup = &_EntityGroup{
+			index: map[uid.Id]struct{}{},
+		}
+		mgr.groups.groups[group] = entityGroup
+	}
+
+	if _, ok := entityGroup.index[entityId]; ok {
+		mgr.groups.mutex.Unlock()
+		return nil
+	}
+
+	entityGroup.members = append(entityGroup.members, entity)
+	entityGroup.index[entityId] = struct{}{}
+	mgr.groups.memberships[entityId] = append(mgr.groups.memberships[entityId], group)
+
+	mgr.groups.mutex.Unlock()
+
+	mgr.notifyGroupWatchers(&mgr.groups.joinWatchers, _EntityGroupMembership{group: group, entity: entity})
+
+	return nil
+}
+
+// RemoveFromGroup 实体离开分组，分组为空时将自动删除
+func (mgr *_EntityManagerBehavior) RemoveFromGroup(group string, entityId uid.Id) bool {
+	mgr.groups.mutex.Lock()
+	entity, ok := mgr.leaveGroup(group, entityId)
+	mgr.groups.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	mgr.notifyGroupWatchers(&mgr.groups.leaveWatchers, _EntityGroupMembership{group: group, entity: entity})
+
+	return true
+}
+
+// DeleteGroup 删除分组，所有成员实体将离开分组
+func (mgr *_EntityManagerBehavior) DeleteGroup(group string) {
+	var left []_EntityGroupMembership
+
+	mgr.groups.mutex.Lock()
+	if entityGroup, ok := mgr.groups.groups[group]; ok {
+		for _, member := range slices.Clone(entityGroup.members) {
+			if entity, ok := mgr.leaveGroup(group, member.GetId()); ok {
+				left = append(left, _EntityGroupMembership{group: group, entity: entity})
+			}
+		}
+	}
+	mgr.groups.mutex.Unlock()
+
+	mgr.notifyGroupWatchers(&mgr.groups.leaveWatchers, left...)
+}
+
+// ContainsInGroup 分组中是否包含实体
+func (mgr *_EntityManagerBehavior) ContainsInGroup(group string, entityId uid.Id) bool {
+	mgr.groups.mutex.RLock()
+	defer mgr.groups.mutex.RUnlock()
+
+	entityGroup, ok := mgr.groups.groups[group]
+	if !ok {
+		return false
+	}
+	_, ok = entityGroup.index[entityId]
+	return ok
+}
+
+// RangeGroup 按加入顺序遍历分组中的实体
+func (mgr *_EntityManagerBehavior) RangeGroup(group string, fun generic.Func1[ec.ConcurrentEntity, bool]) {
+	for _, entity := range mgr.GetGroupEntities(group) {
+		if !fun.UnsafeCall(entity) {
+			return
+		}
+	}
+}
+
+// GetGroupEntities 按加入顺序获取分组中的所有实体
+func (mgr *_EntityManagerBehavior) GetGroupEntities(group string) []ec.ConcurrentEntity {
+	mgr.groups.mutex.RLock()
+	defer mgr.groups.mutex.RUnlock()
+
+	entityGroup, ok := mgr.groups.groups[group]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(entityGroup.members)
+}
+
+// CountGroup 获取分组中的实体数量
+func (mgr *_EntityManagerBehavior) CountGroup(group string) int {
+	mgr.groups.mutex.RLock()
+	defer mgr.groups.mutex.RUnlock()
+
+	entityGroup, ok := mgr.groups.groups[group]
+	if !ok {
+		return 0
+	}
+	return len(entityGroup.members)
+}
+
+// GetJoinedGroups 获取实体加入的所有分组
+func (mgr *_EntityManagerBehavior) GetJoinedGroups(entityId uid.Id) []string {
+	mgr.groups.mutex.RLock()
+	defer mgr.groups.mutex.RUnlock()
+
+	return slices.Clone(mgr.groups.memberships[entityId])
+}
+
+// WatchGroupJoin 监听实体加入分组，返回取消监听的函数，回调在调用方的goroutine中执行
+func (mgr *_EntityManagerBehavior) WatchGroupJoin(fun generic.Action2[string, ec.ConcurrentEntity]) func() {
+	return mgr.watchGroup(&mgr.groups.joinWatchers, fun)
+}
+
+// WatchGroupLeave 监听实体离开分组，返回取消监听的函数，回调在调用方的goroutine中执行
+func (mgr *_EntityManagerBehavior) WatchGroupLeave(fun generic.Action2[string, ec.ConcurrentEntity]) func() {
+	return mgr.watchGroup(&mgr.groups.leaveWatchers, fun)
+}
+
+func (mgr *_EntityManagerBehavior) leaveGroup(group string, entityId uid.Id) (ec.ConcurrentEntity, bool) {
+	entityGroup, ok := mgr.groups.groups[group]
+	if !ok {
+		return nil, false
+	}
+
+	if _, ok := entityGroup.index[entityId]; !ok {
+		return nil, false
+	}
+
+	var entity ec.ConcurrentEntity
+
+	delete(entityGroup.index, entityId)
+	if idx := slices.IndexFunc(entityGroup.members, func(member ec.ConcurrentEntity) bool { return member.GetId() == entityId }); idx >= 0 {
+		entity = entityGroup.members[idx]
+		entityGroup.members = slices.Delete(entityGroup.members, idx, idx+1)
+	}
+	if len(entityGroup.members) <= 0 {
+		delete(mgr.groups.groups, group)
+	}
+
+	groups := mgr.groups.memberships[entityId]
+	if idx := slices.Index(groups, group); idx >= 0 {
+		groups = slices.Delete(groups, idx, idx+1)
+	}
+	if len(groups) > 0 {
+		mgr.groups.memberships[entityId] = groups
+	} else {
+		delete(mgr.groups.memberships, entityId)
+	}
+
+	return entity, true
+}
+
+func (mgr *_EntityManagerBehavior) leaveAllGroups(entityId uid.Id) {
+	var left []_EntityGroupMembership
+
+	mgr.groups.mutex.Lock()
+	for _, group := range slices.Clone(mgr.groups.memberships[entityId]) {
+		if entity, ok := mgr.leaveGroup(group, entityId); ok {
+			left = append(left, _EntityGroupMembership{group: group, entity: entity})
+		}
+	}
+	mgr.groups.mutex.Unlock()
+
+	mgr.notifyGroupWatchers(&mgr.groups.leaveWatchers, left...)
+}
+
+func (mgr *_EntityManagerBehavior) watchGroup(watchers *[]*_EntityGroupWatcher, fun generic.Action2[string, ec.ConcurrentEntity]) func() {
+	if fun == nil {
+		return func() {}
+	}
+
+	mgr.groups.watcherMutex.Lock()
+	defer mgr.groups.watcherMutex.Unlock()
+
+	mgr.groups.watcherHandle++
+	handle := mgr.groups.watcherHandle
+
+	*watchers = append(*watchers, &_EntityGroupWatcher{
+		handle: handle,
+		fun:    fun,
+	})
+
+	return func() {
+		mgr.groups.watcherMutex.Lock()
+		defer mgr.groups.watcherMutex.Unlock()
+
+		*watchers = slices.DeleteFunc(*watchers, func(watcher *_EntityGroupWatcher) bool {
+			return watcher.handle == handle
+		})
+	}
+}
+
+func (mgr *_EntityManagerBehavior) notifyGroupWatchers(watchers *[]*_EntityGroupWatcher, memberships ..._EntityGroupMembership) {
+	if len(memberships) <= 0 {
+		return
+	}
+
+	mgr.groups.watcherMutex.RLock()
+	copied := slices.Clone(*watchers)
+	mgr.groups.watcherMutex.RUnlock()
+
+	for _, membership := range memberships {
+		for _, watcher := range copied {
+			watcher.fun.Call(mgr.ctx.GetAutoRecover(), mgr.ctx.GetReportError(), membership.group, membership.entity)
+		}
+	}
+}
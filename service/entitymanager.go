@@ -36,6 +36,8 @@ type EntityManager interface {
 	GetEntity(id uid.Id) (ec.ConcurrentEntity, bool)
 	// GetOrAddEntity 查询或添加实体
 	GetOrAddEntity(entity ec.ConcurrentEntity) (ec.ConcurrentEntity, bool, error)
+	// GetOrAddEntities 批量查询或添加实体，实体已存在时返回错误，返回与实体一一对应的错误，逐个添加，不保证整批原子性
+	GetOrAddEntities(entities []ec.ConcurrentEntity) []error
 	// AddEntity 添加实体
 	AddEntity(entity ec.ConcurrentEntity) error
 	// GetAndRemoveEntity 查询并删除实体
@@ -47,6 +49,7 @@ type EntityManager interface {
 type _EntityManagerBehavior struct {
 	ctx      Context
 	entities sync.Map
+	groups   _EntityGroupsBehavior
 }
 
 func (mgr *_EntityManagerBehavior) init(ctx Context) {
@@ -55,6 +58,8 @@ func (mgr *_EntityManagerBehavior) init(ctx Context) {
 	}
 
 	mgr.ctx = ctx
+	mgr.groups.groups = map[string]*_EntityGroup{}
+	mgr.groups.memberships = map[uid.Id][]string{}
 }
 
 // GetContext 获取服务上下文
@@ -74,20 +79,61 @@ func (mgr *_EntityManagerBehavior) GetEntity(id uid.Id) (ec.ConcurrentEntity, bo
 
 // GetOrAddEntity 查询或添加实体
 func (mgr *_EntityManagerBehavior) GetOrAddEntity(entity ec.ConcurrentEntity) (ec.ConcurrentEntity, bool, error) {
+	if err := mgr.checkEntity(entity); err != nil {
+		return nil, false, err
+	}
+
+	actual, loaded := mgr.entities.LoadOrStore(entity.GetId(), entity)
+	return actual.(ec.ConcurrentEntity), loaded, nil
+}
+
+// GetOrAddEntities 批量查询或添加实体，实体已存在时返回错误，返回与实体一一对应的错误。先校验全部实体，再逐个添加，
+// 校验失败的实体不会添加，其余实体的添加互相独立，不保证整批原子性
+func (mgr *_EntityManagerBehavior) GetOrAddEntities(entities []ec.ConcurrentEntity) []error {
+	errs := make([]error, len(entities))
+
+	pending := make(map[uid.Id]struct{}, len(entities))
+
+	for i, entity := range entities {
+		if err := mgr.checkEntity(entity); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if _, ok := pending[entity.GetId()]; ok {
+			errs[i] = fmt.Errorf("%w: entity %q already exists", ErrEntityManager, entity.GetId())
+			continue
+		}
+		pending[entity.GetId()] = struct{}{}
+	}
+
+	for i, entity := range entities {
+		if errs[i] != nil {
+			continue
+		}
+
+		if _, loaded := mgr.entities.LoadOrStore(entity.GetId(), entity); loaded {
+			errs[i] = fmt.Errorf("%w: entity %q already exists", ErrEntityManager, entity.GetId())
+		}
+	}
+
+	return errs
+}
+
+func (mgr *_EntityManagerBehavior) checkEntity(entity ec.ConcurrentEntity) error {
 	if entity == nil {
-		return nil, false, fmt.Errorf("%w: %w: entity is nil", ErrEntityManager, exception.ErrArgs)
+		return fmt.Errorf("%w: %w: entity is nil", ErrEntityManager, exception.ErrArgs)
 	}
 
 	if entity.GetId().IsNil() {
-		return nil, false, fmt.Errorf("%w: entity id is nil", ErrEntityManager)
+		return fmt.Errorf("%w: entity id is nil", ErrEntityManager)
 	}
 
 	if entity.GetConcurrentContext() == iface.NilCache {
-		return nil, false, fmt.Errorf("%w: entity context is nil", ErrEntityManager)
+		return fmt.Errorf("%w: entity context is nil", ErrEntityManager)
 	}
 
-	actual, loaded := mgr.entities.LoadOrStore(entity.GetId(), entity)
-	return actual.(ec.ConcurrentEntity), loaded, nil
+	return nil
 }
 
 // GetAndRemoveEntity 查询并删除实体
@@ -96,29 +142,24 @@ func (mgr *_EntityManagerBehavior) GetAndRemoveEntity(id uid.Id) (ec.ConcurrentE
 	if !loaded {
 		return nil, false
 	}
+	mgr.leaveAllGroups(id)
 	return v.(ec.ConcurrentEntity), true
 }
 
 // AddEntity 添加实体
 func (mgr *_EntityManagerBehavior) AddEntity(entity ec.ConcurrentEntity) error {
-	if entity == nil {
-		return fmt.Errorf("%w: %w: entity is nil", ErrEntityManager, exception.ErrArgs)
+	if err := mgr.checkEntity(entity); err != nil {
+		return err
 	}
 
-	if entity.GetId().IsNil() {
-		return fmt.Errorf("%w: entity id is nil", ErrEntityManager)
+	if old, loaded := mgr.entities.Swap(entity.GetId(), entity); loaded && old != entity {
+		mgr.leaveAllGroups(entity.GetId())
 	}
 
-	if entity.GetConcurrentContext() == iface.NilCache {
-		return fmt.Errorf("%w: entity context is nil", ErrEntityManager)
-	}
-
-	mgr.entities.Store(entity.GetId(), entity)
-
 	return nil
 }
 
 // RemoveEntity 删除实体
 func (mgr *_EntityManagerBehavior) RemoveEntity(id uid.Id) {
-	mgr.entities.Delete(id)
+	mgr.GetAndRemoveEntity(id)
 }
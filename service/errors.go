@@ -27,4 +27,5 @@ import (
 var (
 	ErrContext       = fmt.Errorf("%w: service-context", exception.ErrCore) // 服务上下文错误
 	ErrEntityManager = fmt.Errorf("%w: entity-manager", ErrContext)         // 实体管理器错误
+	ErrEntityGroup   = fmt.Errorf("%w: entity-group", ErrContext)           // 实体分组错误
 )
@@ -19,6 +19,10 @@
 
 package service
 
+import (
+	"git.golaxy.org/core/utils/generic"
+)
+
 // Deprecated: UnsafeContext 访问服务上下文内部方法
 func UnsafeContext(ctx Context) _UnsafeContext {
 	return _UnsafeContext{
@@ -44,3 +48,8 @@ func (u _UnsafeContext) GetOptions() *ContextOptions {
 func (u _UnsafeContext) ChangeRunningStatus(status RunningStatus, args ...any) {
 	u.changeRunningStatus(status, args...)
 }
+
+// WatchRunningStatus 监听运行状态变化，支持在任意线程中调用，返回取消监听函数
+func (u _UnsafeContext) WatchRunningStatus(fun generic.ActionVar1[RunningStatus, any]) func() {
+	return u.watchRunningStatus(fun)
+}